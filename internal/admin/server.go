@@ -0,0 +1,124 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin provides HTTP server for nsmgr status and administration endpoints
+package admin
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Server - admin HTTP server, handlers can be added before or after it is started
+type Server struct {
+	mux       *http.ServeMux
+	tokenFile string
+}
+
+// Option - Server option
+type Option func(s *Server)
+
+// WithTokenFile - sets path to the file with the bearer token required by the authenticated handlers. The file is
+// read on every request, so the token can be rotated without restart.
+func WithTokenFile(tokenFile string) Option {
+	return func(s *Server) {
+		s.tokenFile = tokenFile
+	}
+}
+
+// NewServer - creates admin Server
+func NewServer(opts ...Option) *Server {
+	s := &Server{mux: http.NewServeMux()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Handle - registers the handler for the pattern
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// HandleFunc - registers the handler function for the pattern
+func (s *Server) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+// HandleAuthenticated - registers the handler for the pattern, requests must have "Authorization: Bearer <token>"
+// header with the token from the token file. The handler is disabled if the token file is not set.
+func (s *Server) HandleAuthenticated(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.tokenFile == "" {
+			http.Error(w, "admin token file is not configured", http.StatusForbidden)
+			return
+		}
+		token, err := os.ReadFile(s.tokenFile)
+		if err != nil {
+			log.FromContext(r.Context()).Errorf("Failed to read admin token file: %s", err.Error())
+			http.Error(w, "admin token is not available", http.StatusInternalServerError)
+			return
+		}
+		token = bytes.TrimSpace(token)
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || len(token) == 0 || subtle.ConstantTimeCompare([]byte(bearer), token) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+}
+
+// ServeHTTP - serves the request with the registered handlers
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe - starts serving on listenOn until ctx is done
+func (s *Server) ListenAndServe(ctx context.Context, listenOn string) {
+	server := &http.Server{
+		Addr:              listenOn,
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		log.FromContext(ctx).Infof("Admin server is listening on %s", listenOn)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.FromContext(ctx).Errorf("Failed to start admin server: %s", err.Error())
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+}
+
+// WriteJSON - writes v as indented JSON response
+func WriteJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(v)
+}
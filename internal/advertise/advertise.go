@@ -0,0 +1,340 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package advertise selects the URL nsmgr is published with in the registry
+package advertise
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// IPv4 - prefer IPv4 addresses
+	IPv4 = "ipv4"
+	// IPv6 - prefer IPv6 addresses
+	IPv6 = "ipv6"
+
+	tcpScheme = "tcp"
+	// altQuery - query parameter of the advertised URL with the URL of the other IP family. The registry API has no
+	// field for it, so it is understood only by NewNSEFindServer of this nsmgr, other components dial the primary URL
+	// (sdk drops the query of tcp URLs on dial) and see the whole URL with the query otherwise.
+	altQuery = "alt"
+)
+
+// Interface - network interface with its addresses
+type Interface struct {
+	Name  string
+	Addrs []net.Addr
+}
+
+// Result - advertised URL and the reason why it is selected
+type Result struct {
+	URL *url.URL
+	// Alternate - URL of the other IP family on dual-stack nodes
+	Alternate *url.URL
+	Reason    string
+}
+
+// Published - returns URL to publish in the registry, the alternate URL is passed in the query. The query is private
+// to this nsmgr, see altQuery.
+func (r *Result) Published() *url.URL {
+	if r.Alternate == nil {
+		return r.URL
+	}
+	u := *r.URL
+	query := u.Query()
+	query.Set(altQuery, r.Alternate.String())
+	u.RawQuery = query.Encode()
+	return &u
+}
+
+func (r *Result) String() string {
+	return fmt.Sprintf("%s (%s)", r.Published().String(), r.Reason)
+}
+
+type options struct {
+	url        *url.URL
+	names      []string
+	cidrs      []*net.IPNet
+	family     string
+	dualStack  bool
+	interfaces func() ([]Interface, error)
+}
+
+// Option - Select option
+type Option func(o *options)
+
+// WithURL - advertises u as is, interfaces are not checked
+func WithURL(u *url.URL) Option {
+	return func(o *options) {
+		if u != nil && u.String() != "" {
+			o.url = u
+		}
+	}
+}
+
+// WithInterfaceNames - selects addresses only of the interfaces with names
+func WithInterfaceNames(names []string) Option {
+	return func(o *options) {
+		o.names = names
+	}
+}
+
+// WithCIDRs - selects only addresses from cidrs
+func WithCIDRs(cidrs []*net.IPNet) Option {
+	return func(o *options) {
+		o.cidrs = cidrs
+	}
+}
+
+// WithIPFamily - selects address of family (IPv4 or IPv6) if there is one, IPv4 is preferred by default
+func WithIPFamily(family string) Option {
+	return func(o *options) {
+		o.family = family
+	}
+}
+
+// WithDualStack - selects URLs of both IP families, the URL of the other family is advertised as alternate
+func WithDualStack() Option {
+	return func(o *options) {
+		o.dualStack = true
+	}
+}
+
+// WithInterfaces - sets source of the network interfaces, host interfaces are used by default
+func WithInterfaces(interfaces func() ([]Interface, error)) Option {
+	return func(o *options) {
+		o.interfaces = interfaces
+	}
+}
+
+// ParseCIDRs - parses CIDRs
+func ParseCIDRs(values []string) ([]*net.IPNet, error) {
+	var rv []*net.IPNet
+	for _, value := range values {
+		_, cidr, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid CIDR %q", value)
+		}
+		rv = append(rv, cidr)
+	}
+	return rv, nil
+}
+
+// ValidateIPFamily - returns error if family is not empty, IPv4 or IPv6
+func ValidateIPFamily(family string) error {
+	switch family {
+	case "", IPv4, IPv6:
+		return nil
+	}
+	return errors.Errorf("invalid IP family %q, expected %s or %s", family, IPv4, IPv6)
+}
+
+// Select - returns URL to advertise for the listenOn urls. The first tcp url is used, if it has no host or an
+// unspecified one, the host is replaced with an address of the node selected by the options.
+func Select(listenOn []url.URL, opts ...Option) (*Result, error) {
+	o := &options{
+		family:     IPv4,
+		interfaces: hostInterfaces,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if err := ValidateIPFamily(o.family); err != nil {
+		return nil, err
+	}
+	if o.family == "" {
+		o.family = IPv4
+	}
+
+	if o.url != nil {
+		return &Result{URL: o.url, Reason: "advertise url is configured"}, nil
+	}
+	u := defaultURL(listenOn)
+	if u.Scheme != tcpScheme || u.Port() == "" {
+		return &Result{URL: u, Reason: "there is no tcp listen url"}, nil
+	}
+	if ip := net.ParseIP(u.Hostname()); u.Hostname() != "" && (ip == nil || !ip.IsUnspecified()) {
+		return o.alternate(listenOn, &Result{URL: u, Reason: "listen url has a host"}), nil
+	}
+
+	interfaces, err := o.interfaces()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get network interfaces")
+	}
+	ip, name, ok := o.selectIP(interfaces, o.family, false)
+	if !ok {
+		if len(o.names) > 0 || len(o.cidrs) > 0 {
+			return nil, errors.Errorf("there is no address matching %s", o.selectors())
+		}
+		return &Result{URL: u, Reason: "there is no non-loopback address"}, nil
+	}
+	return o.alternate(listenOn, &Result{
+		URL:    &url.URL{Scheme: tcpScheme, Host: net.JoinHostPort(ip.String(), u.Port())},
+		Reason: fmt.Sprintf("address of interface %s matching %s", name, o.selectors()),
+	}), nil
+}
+
+// alternate - sets URL of the other IP family if dual stack is enabled. It is either a tcp listen url with IP of the
+// family, or an address of the family selected from the interfaces if the first tcp listen url accepts connections
+// of the family on any address.
+func (o *options) alternate(listenOn []url.URL, result *Result) *Result {
+	ip := net.ParseIP(result.URL.Hostname())
+	if !o.dualStack || ip == nil {
+		return result
+	}
+	other := IPv6
+	if familyOf(ip) == IPv6 {
+		other = IPv4
+	}
+	for i := range listenOn {
+		u := &listenOn[i]
+		if ip := net.ParseIP(u.Hostname()); u.Scheme == tcpScheme && ip != nil && !ip.IsUnspecified() && familyOf(ip) == other {
+			result.Alternate = u
+			result.Reason += fmt.Sprintf(", %s listen url", other)
+			return result
+		}
+	}
+	// Empty host and :: accept connections of both families, 0.0.0.0 accepts IPv4 only
+	u := defaultURL(listenOn)
+	if host := u.Hostname(); host == "" || host == "::" || (host == "0.0.0.0" && other == IPv4) {
+		interfaces, err := o.interfaces()
+		if err != nil {
+			result.Reason += fmt.Sprintf(", failed to get network interfaces for dual stack: %v", err)
+			return result
+		}
+		if ip, name, ok := o.selectIP(interfaces, other, true); ok {
+			result.Alternate = &url.URL{Scheme: tcpScheme, Host: net.JoinHostPort(ip.String(), u.Port())}
+			result.Reason += fmt.Sprintf(", %s address of interface %s", other, name)
+			return result
+		}
+	}
+	result.Reason += fmt.Sprintf(", there is no %s address for dual stack", other)
+	return result
+}
+
+// selectIP - returns the first address matching the selectors of the preferred family, or of the other one if
+// there is no such address and strict is not set
+func (o *options) selectIP(interfaces []Interface, preferred string, strict bool) (ip net.IP, name string, ok bool) {
+	for _, iface := range interfaces {
+		if len(o.names) > 0 && !contains(o.names, iface.Name) {
+			continue
+		}
+		for _, addr := range iface.Addrs {
+			candidate := addrIP(addr)
+			if !o.matches(candidate) {
+				continue
+			}
+			if familyOf(candidate) == preferred {
+				return candidate, iface.Name, true
+			}
+			if ip == nil && !strict {
+				ip, name = candidate, iface.Name
+			}
+		}
+	}
+	return ip, name, ip != nil
+}
+
+func (o *options) matches(ip net.IP) bool {
+	if ip == nil || ip.IsLoopback() || !ip.IsGlobalUnicast() {
+		return false
+	}
+	if len(o.cidrs) == 0 {
+		return true
+	}
+	for _, cidr := range o.cidrs {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *options) selectors() string {
+	selectors := []string{"preferred family " + o.family}
+	if len(o.names) > 0 {
+		selectors = append(selectors, "interfaces "+strings.Join(o.names, ","))
+	}
+	if len(o.cidrs) > 0 {
+		var cidrs []string
+		for _, cidr := range o.cidrs {
+			cidrs = append(cidrs, cidr.String())
+		}
+		selectors = append(selectors, "CIDRs "+strings.Join(cidrs, ","))
+	}
+	return strings.Join(selectors, ", ")
+}
+
+func defaultURL(listenOn []url.URL) *url.URL {
+	for i := 0; i < len(listenOn); i++ {
+		u := &listenOn[i]
+		if u.Scheme == tcpScheme {
+			return u
+		}
+	}
+	return &listenOn[0]
+}
+
+func hostInterfaces() ([]Interface, error) {
+	netInterfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	var rv []Interface
+	for i := range netInterfaces {
+		if netInterfaces[i].Flags&net.FlagUp == 0 {
+			continue
+		}
+		addrs, err := netInterfaces[i].Addrs()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get addresses of %s", netInterfaces[i].Name)
+		}
+		rv = append(rv, Interface{Name: netInterfaces[i].Name, Addrs: addrs})
+	}
+	return rv, nil
+}
+
+func addrIP(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.IPNet:
+		return a.IP
+	case *net.IPAddr:
+		return a.IP
+	}
+	return nil
+}
+
+func familyOf(ip net.IP) string {
+	if ip.To4() != nil {
+		return IPv4
+	}
+	return IPv6
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package advertise_test
+
+import (
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/advertise"
+)
+
+func ipNet(cidr string) net.Addr {
+	ip, ipnet, _ := net.ParseCIDR(cidr)
+	ipnet.IP = ip
+	return ipnet
+}
+
+func interfaces() ([]advertise.Interface, error) {
+	return []advertise.Interface{
+		{Name: "lo", Addrs: []net.Addr{ipNet("127.0.0.1/8"), ipNet("::1/128")}},
+		{Name: "eth0", Addrs: []net.Addr{ipNet("fe80::1/64"), ipNet("fd00::10/64"), ipNet("10.0.0.10/24")}},
+		{Name: "eth1", Addrs: []net.Addr{ipNet("192.168.1.10/24")}},
+	}, nil
+}
+
+func listenOn(urls ...string) []url.URL {
+	var rv []url.URL
+	for _, u := range urls {
+		parsed, _ := url.Parse(u)
+		rv = append(rv, *parsed)
+	}
+	return rv
+}
+
+func TestSelect(t *testing.T) {
+	advertiseURL, _ := url.Parse("tcp://nsmgr.example.org:5001")
+	cidrs, err := advertise.ParseCIDRs([]string{"192.168.0.0/16"})
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		name     string
+		listenOn []url.URL
+		opts     []advertise.Option
+		expected string
+	}{
+		{
+			name:     "first IPv4",
+			listenOn: listenOn("unix:///nsm.io.sock", "tcp://:5001"),
+			expected: "tcp://10.0.0.10:5001",
+		},
+		{
+			name:     "unspecified host",
+			listenOn: listenOn("tcp://0.0.0.0:5001"),
+			expected: "tcp://10.0.0.10:5001",
+		},
+		{
+			name:     "IPv6 preferred",
+			listenOn: listenOn("tcp://:5001"),
+			opts:     []advertise.Option{advertise.WithIPFamily(advertise.IPv6)},
+			expected: "tcp://[fd00::10]:5001",
+		},
+		{
+			name:     "IPv6 preferred, there is no IPv6 on the interface",
+			listenOn: listenOn("tcp://:5001"),
+			opts:     []advertise.Option{advertise.WithIPFamily(advertise.IPv6), advertise.WithInterfaceNames([]string{"eth1"})},
+			expected: "tcp://192.168.1.10:5001",
+		},
+		{
+			name:     "CIDR",
+			listenOn: listenOn("tcp://:5001"),
+			opts:     []advertise.Option{advertise.WithCIDRs(cidrs)},
+			expected: "tcp://192.168.1.10:5001",
+		},
+		{
+			name:     "listen url with host",
+			listenOn: listenOn("tcp://10.0.0.20:5001"),
+			expected: "tcp://10.0.0.20:5001",
+		},
+		{
+			name:     "advertise url",
+			listenOn: listenOn("tcp://:5001"),
+			opts:     []advertise.Option{advertise.WithURL(advertiseURL)},
+			expected: "tcp://nsmgr.example.org:5001",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := advertise.Select(test.listenOn, append([]advertise.Option{advertise.WithInterfaces(interfaces)}, test.opts...)...)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, result.URL.String())
+			require.NotEmpty(t, result.Reason)
+		})
+	}
+}
+
+func TestSelect_DualStack(t *testing.T) {
+	result, err := advertise.Select(listenOn("tcp://:5001"), advertise.WithInterfaces(interfaces), advertise.WithDualStack())
+	require.NoError(t, err)
+	require.Equal(t, "tcp://10.0.0.10:5001", result.URL.String())
+	require.Equal(t, "tcp://[fd00::10]:5001", result.Alternate.String())
+
+	published := result.Published()
+	require.Equal(t, "tcp://10.0.0.10:5001", advertise.ForFamily(published, advertise.IPv4).String())
+	require.Equal(t, "tcp://[fd00::10]:5001", advertise.ForFamily(published, advertise.IPv6).String())
+
+	result, err = advertise.Select(listenOn("tcp://[fd00::20]:5001", "tcp://10.0.0.20:5001"), advertise.WithDualStack())
+	require.NoError(t, err)
+	require.Equal(t, "tcp://[fd00::20]:5001", result.URL.String())
+	require.Equal(t, "tcp://10.0.0.20:5001", result.Alternate.String())
+
+	// 0.0.0.0 doesn't accept IPv6 connections
+	result, err = advertise.Select(listenOn("tcp://0.0.0.0:5001"), advertise.WithInterfaces(interfaces), advertise.WithDualStack())
+	require.NoError(t, err)
+	require.Nil(t, result.Alternate)
+	require.Equal(t, result.URL, result.Published())
+}
+
+func TestSelect_NoMatch(t *testing.T) {
+	_, err := advertise.Select(listenOn("tcp://:5001"), advertise.WithInterfaces(interfaces), advertise.WithInterfaceNames([]string{"eth2"}))
+	require.Error(t, err)
+
+	_, err = advertise.Select(listenOn("tcp://:5001"), advertise.WithIPFamily("ipx"))
+	require.Error(t, err)
+}
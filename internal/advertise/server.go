@@ -0,0 +1,94 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package advertise
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+// ForFamily - returns URL of family from the published u, the primary URL if there is no URL of the family
+func ForFamily(u *url.URL, family string) *url.URL {
+	query := u.Query()
+	if !query.Has(altQuery) {
+		return u
+	}
+	primary := *u
+	query.Del(altQuery)
+	primary.RawQuery = query.Encode()
+
+	if ip := net.ParseIP(primary.Hostname()); ip == nil || familyOf(ip) == family {
+		return &primary
+	}
+	alternate, err := url.Parse(u.Query().Get(altQuery))
+	if err != nil {
+		return &primary
+	}
+	if ip := net.ParseIP(alternate.Hostname()); ip == nil || familyOf(ip) != family {
+		return &primary
+	}
+	return alternate
+}
+
+type nseFindServer struct {
+	family string
+}
+
+// NewNSEFindServer - returns NSE registry server element replacing dual-stack URLs of the found endpoints, e.g.
+// remote nsmgrs, with the URL of family, so the local forwarders dial the address they can reach
+func NewNSEFindServer(family string) registry.NetworkServiceEndpointRegistryServer {
+	return &nseFindServer{family: family}
+}
+
+func (s *nseFindServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *nseFindServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, &familyFindServer{
+		NetworkServiceEndpointRegistry_FindServer: server,
+		family: s.family,
+	})
+}
+
+func (s *nseFindServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*emptypb.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+type familyFindServer struct {
+	registry.NetworkServiceEndpointRegistry_FindServer
+	family string
+}
+
+func (s *familyFindServer) Send(nseResp *registry.NetworkServiceEndpointResponse) error {
+	if nse := nseResp.GetNetworkServiceEndpoint(); nse != nil {
+		if u, err := url.Parse(nse.GetUrl()); err == nil && u.Query().Has(altQuery) {
+			nseResp = &registry.NetworkServiceEndpointResponse{
+				NetworkServiceEndpoint: nse.Clone(),
+				Deleted:                nseResp.GetDeleted(),
+			}
+			nseResp.NetworkServiceEndpoint.Url = ForFamily(u, s.family).String()
+		}
+	}
+	return s.NetworkServiceEndpointRegistry_FindServer.Send(nseResp)
+}
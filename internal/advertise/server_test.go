@@ -0,0 +1,59 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package advertise_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/advertise"
+)
+
+func TestNSEFindServer(t *testing.T) {
+	ctx := context.Background()
+	server := chain.NewNetworkServiceEndpointRegistryServer(
+		advertise.NewNSEFindServer(advertise.IPv6),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+	for name, u := range map[string]string{
+		"remote-nse": "tcp://10.0.0.10:5001?alt=tcp%3A%2F%2F%5Bfd00%3A%3A10%5D%3A5001",
+		"legacy-nse": "tcp://10.0.0.20:5001",
+	} {
+		_, err := server.Register(ctx, &registry.NetworkServiceEndpoint{Name: name, Url: u})
+		require.NoError(t, err)
+	}
+
+	stream, err := adapters.NetworkServiceEndpointServerToClient(server).Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+	})
+	require.NoError(t, err)
+	urls := make(map[string]string)
+	for _, nse := range registry.ReadNetworkServiceEndpointList(stream) {
+		urls[nse.GetName()] = nse.GetUrl()
+	}
+	require.Equal(t, map[string]string{
+		"remote-nse": "tcp://[fd00::10]:5001",
+		"legacy-nse": "tcp://10.0.0.20:5001",
+	}, urls)
+}
@@ -2,6 +2,8 @@
 //
 // Copyright (c) 2023-2025 Cisco and/or its affiliates.
 //
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
 // SPDX-License-Identifier: Apache-2.0
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -25,18 +27,63 @@ import (
 )
 
 // Config - configuration for cmd-nsmgr
+// Fields tagged with reload:"true" can be applied at runtime without restart, see Diff
 type Config struct {
-	Name                        string        `default:"nmgr" desc:"Name of Network service manager"`
-	ListenOn                    []url.URL     `default:"unix:///var/lib/networkservicemesh/nsm.io.sock" desc:"url to listen on. tcp:// one will be used a public to register NSM." split_words:"true"`
-	RegistryURL                 url.URL       `default:"tcp://localhost:5001" desc:"A NSE registry url to use" split_words:"true"`
-	MaxTokenLifetime            time.Duration `default:"10m" desc:"maximum lifetime of tokens" split_words:"true"`
-	RegistryServerPolicies      []string      `default:"etc/nsm/opa/common/.*.rego,etc/nsm/opa/registry/.*.rego,etc/nsm/opa/server/.*.rego" desc:"paths to files and directories that contain registry server policies" split_words:"true"`
-	RegistryClientPolicies      []string      `default:"etc/nsm/opa/common/.*.rego,etc/nsm/opa/registry/.*.rego,etc/nsm/opa/client/.*.rego" desc:"paths to files and directories that contain registry client policies" split_words:"true"`
-	LogLevel                    string        `default:"INFO" desc:"Log level" split_words:"true"`
-	DialTimeout                 time.Duration `default:"750ms" desc:"Timeout for the dial the next endpoint" split_words:"true"`
-	ForwarderNetworkServiceName string        `default:"forwarder" desc:"the default service name for forwarder discovering" split_words:"true"`
-	OpenTelemetryEndpoint       string        `default:"otel-collector.observability.svc.cluster.local:4317" desc:"OpenTelemetry Collector Endpoint" split_words:"true"`
-	MetricsExportInterval       time.Duration `default:"10s" desc:"interval between mertics exports" split_words:"true"`
-	PprofEnabled                bool          `default:"false" desc:"is pprof enabled" split_words:"true"`
-	PprofListenOn               string        `default:"localhost:6060" desc:"pprof URL to ListenAndServe" split_words:"true"`
+	ConfigFile                  string        `default:"" desc:"path to a YAML or JSON file with configuration, environment variables take precedence over it" split_words:"true" json:"-"`
+	Name                        string        `default:"nmgr" desc:"Name of Network service manager" json:"name"`
+	ListenOn                    []url.URL     `default:"unix:///var/lib/networkservicemesh/nsm.io.sock" desc:"url to listen on. tcp:// one will be used a public to register NSM." split_words:"true" json:"listenOn"`
+	UnixSocketDirMode           string        `default:"0755" desc:"mode of the directories created for the unix listen urls" split_words:"true" json:"unixSocketDirMode"`
+	UnixSocketMode              string        `default:"0777" desc:"mode of the unix listen sockets" split_words:"true" json:"unixSocketMode"`
+	UnixSocketOwner             string        `default:"" desc:"owner (name or UID) of the unix listen sockets and of the directories created for them, empty means not changed" split_words:"true" json:"unixSocketOwner"`
+	UnixSocketGroup             string        `default:"" desc:"group (name or GID) of the unix listen sockets and of the directories created for them, empty means not changed" split_words:"true" json:"unixSocketGroup"`
+	ListenerRestartBackoff      time.Duration `default:"1s" desc:"delay before the first restart of a failed listener, doubled on each failure in a row" split_words:"true" json:"listenerRestartBackoff"`
+	ListenerRestartMaxBackoff   time.Duration `default:"30s" desc:"maximum delay between restarts of a failed listener, failures are reset when the listener serves for this time" split_words:"true" json:"listenerRestartMaxBackoff"`
+	ListenerMaxRestarts         int           `default:"5" desc:"a critical listener failing more than this number of times in a row stops nsmgr, 0 means stop on the first failure" split_words:"true" json:"listenerMaxRestarts"`
+	CriticalListeners           []string      `default:"unix" desc:"schemes or listen urls of the listeners whose failure stops nsmgr, the others are restarted forever" split_words:"true" json:"criticalListeners"`
+	AdvertiseURL                url.URL       `default:"" desc:"url nsmgr is published with in the registry, overrides the address selected from the tcp listen url" split_words:"true" json:"advertiseURL"`
+	AdvertiseInterfaces         []string      `default:"" desc:"names of the network interfaces to select the advertised address from when the tcp listen url has no host, empty means any" split_words:"true" json:"advertiseInterfaces"`
+	AdvertiseCIDRs              []string      `default:"" desc:"CIDRs to select the advertised address from when the tcp listen url has no host, empty means any" split_words:"true" json:"advertiseCIDRs"`
+	AdvertiseIPFamily           string        `default:"ipv4" desc:"preferred family of the advertised address and of the dual-stack remote urls dialed by forwarders: ipv4 or ipv6, the other one is used if there is no address of the preferred family" split_words:"true" json:"advertiseIPFamily"`
+	AdvertiseDualStack          bool          `default:"false" desc:"advertise urls of both IP families, the url of the other family is passed in alt query parameter of the advertised url" split_words:"true" json:"advertiseDualStack"`
+	RegistryURL                 url.URL       `default:"tcp://localhost:5001" desc:"A NSE registry url to use" split_words:"true" json:"registryURL"`
+	Standalone                  bool          `default:"false" desc:"run without external registry, network services and endpoints are kept in the embedded in-memory registry served on the nsmgr listeners, implied by empty registry url" json:"standalone"`
+	RegistryFailoverURLs        []url.URL     `default:"" desc:"registry urls to fail over to in priority order when the registry url is not healthy" envconfig:"registry_failover_urls" json:"registryFailoverURLs"`
+	RegistryHealthCheckInterval time.Duration `default:"5s" desc:"interval between registry health checks, used when registry failover urls are set" split_words:"true" json:"registryHealthCheckInterval"`
+	RegistryFailbackDelay       time.Duration `default:"30s" desc:"time a registry with higher priority has to stay healthy before switching back to it, 0 disables fail-back" split_words:"true" json:"registryFailbackDelay"`
+	PersistEndpoints            bool          `default:"true" desc:"save NSEs and forwarders registered on nsmgr to a file in the directory of the unix listen url and restore them on restart" split_words:"true" json:"persistEndpoints"`
+	CheckpointConnections       bool          `default:"true" desc:"save connections brokered by nsmgr to a file in the directory of the unix listen url and re-adopt them on restart" split_words:"true" json:"checkpointConnections"`
+	RequestRateLimit            float64       `default:"0" desc:"NetworkService Request calls per second allowed for each client SPIFFE ID, 0 means unlimited" split_words:"true" json:"requestRateLimit"`
+	RequestRateBurst            int           `default:"10" desc:"NetworkService Request calls allowed at once for each client SPIFFE ID above the request rate limit" split_words:"true" json:"requestRateBurst"`
+	RegisterRateLimit           float64       `default:"0" desc:"registry Register calls per second allowed for each client SPIFFE ID, 0 means unlimited" split_words:"true" json:"registerRateLimit"`
+	RegisterRateBurst           int           `default:"10" desc:"registry Register calls allowed at once for each client SPIFFE ID above the register rate limit" split_words:"true" json:"registerRateBurst"`
+	MaxClientConnections        int           `default:"0" desc:"maximum number of concurrent connections of each client SPIFFE ID, 0 means unlimited" split_words:"true" json:"maxClientConnections"`
+	RateLimitExemptPaths        []string      `default:"" desc:"regular expressions for SPIFFE ID paths of peers not limited by the rate limits and the connection quotas, e.g. forwarders calling remote nsmgrs, nsmgr own SPIFFE ID is always exempt" split_words:"true" json:"rateLimitExemptPaths"`
+	MaxTokenLifetime            time.Duration `default:"10m" desc:"maximum lifetime of tokens" split_words:"true" json:"maxTokenLifetime" reload:"true"`
+	RegistryServerPolicies      []string      `default:"etc/nsm/opa/common/.*.rego,etc/nsm/opa/registry/.*.rego,etc/nsm/opa/server/.*.rego" desc:"paths to files and directories that contain registry server policies" split_words:"true" json:"registryServerPolicies" reload:"true"`
+	RegistryClientPolicies      []string      `default:"etc/nsm/opa/common/.*.rego,etc/nsm/opa/registry/.*.rego,etc/nsm/opa/client/.*.rego" desc:"paths to files and directories that contain registry client policies" split_words:"true" json:"registryClientPolicies" reload:"true"`
+	NetworkServicePolicies      []string      `default:"etc/nsm/opa/common/.*.rego,etc/nsm/opa/server/.*.rego" desc:"paths to files and directories that contain NetworkService policies" envconfig:"networkservice_policies" json:"networkServicePolicies" reload:"true"`
+	MonitorConnectionPolicies   []string      `default:"" desc:"paths to files and directories that contain MonitorConnection policies" split_words:"true" json:"monitorConnectionPolicies" reload:"true"`
+	LogLevel                    string        `default:"INFO" desc:"Log level" split_words:"true" json:"logLevel" reload:"true"`
+	LogFormat                   string        `default:"text" desc:"Log output format: text or json" split_words:"true" json:"logFormat"`
+	DialTimeout                 time.Duration `default:"750ms" desc:"Timeout for the dial the next endpoint" split_words:"true" json:"dialTimeout" reload:"true"`
+	ForwarderNetworkServiceName string        `default:"forwarder" desc:"the default service name for forwarder discovering" split_words:"true" json:"forwarderNetworkServiceName"`
+	OpenTelemetryEndpoint       string        `default:"otel-collector.observability.svc.cluster.local:4317" desc:"OpenTelemetry Collector Endpoint" split_words:"true" json:"openTelemetryEndpoint"`
+	MetricsExportInterval       time.Duration `default:"10s" desc:"interval between mertics exports" split_words:"true" json:"metricsExportInterval"`
+	MetricsListenOn             string        `default:"" desc:"address to serve metrics in Prometheus format on /metrics, empty means disabled" split_words:"true" json:"metricsListenOn"`
+	PprofEnabled                bool          `default:"false" desc:"is pprof enabled" split_words:"true" json:"pprofEnabled" reload:"true"`
+	PprofListenOn               string        `default:"localhost:6060" desc:"pprof URL to ListenAndServe" split_words:"true" json:"pprofListenOn" reload:"true"`
+	AdminListenOn               string        `default:"" desc:"address to serve admin HTTP endpoints on, empty means disabled" split_words:"true" json:"adminListenOn"`
+	AdminTokenFile              string        `default:"" desc:"path to a file with the bearer token required by the admin endpoints changing nsmgr state or exposing client identities, they are disabled if empty" split_words:"true" json:"adminTokenFile"`
+	IdentitySource              string        `default:"workloadapi" desc:"source of the nsmgr X.509 SVID: workloadapi (SPIFFE Workload API) or file" split_words:"true" json:"identitySource"`
+	X509CertFile                string        `default:"" desc:"path to the X.509 SVID certificate chain PEM file, used by the file identity source" split_words:"true" json:"x509CertFile"`
+	X509KeyFile                 string        `default:"" desc:"path to the X.509 SVID ECDSA private key PEM file, used by the file identity source" split_words:"true" json:"x509KeyFile"`
+	X509BundleFile              string        `default:"" desc:"path to the X.509 trust bundle PEM file, used by the file identity source" split_words:"true" json:"x509BundleFile"`
+	InboundTrustDomains         []string      `default:"" desc:"trust domains allowed for mTLS peers connecting to nsmgr, empty means any" split_words:"true" json:"inboundTrustDomains"`
+	InboundSpiffeIDPaths        []string      `default:"" desc:"regular expressions for SPIFFE ID paths allowed for mTLS peers connecting to nsmgr, empty means any" split_words:"true" json:"inboundSpiffeIDPaths"`
+	OutboundTrustDomains        []string      `default:"" desc:"trust domains allowed for mTLS peers dialed by nsmgr (registry, forwarders, remote nsmgrs), empty means any" split_words:"true" json:"outboundTrustDomains"`
+	OutboundSpiffeIDPaths       []string      `default:"" desc:"regular expressions for SPIFFE ID paths allowed for mTLS peers dialed by nsmgr (registry, forwarders, remote nsmgrs), empty means any" split_words:"true" json:"outboundSpiffeIDPaths"`
+	PeerUIDNetworkServices      []string      `default:"" desc:"network services allowed by UID for callers connected over unix sockets without SPIFFE ID, e.g. 1000:icmp-responder|vl3,0:*, empty means the policies decide" split_words:"true" json:"peerUIDNetworkServices"`
+	WorkloadAPITimeout          time.Duration `default:"5m" desc:"total time to wait for the SPIFFE Workload API at startup, 0 means wait until nsmgr is stopped" split_words:"true" json:"workloadAPITimeout"`
+	ReadinessCheckInterval      time.Duration `default:"5s" desc:"interval between readiness checks of registry, forwarder and SVID" split_words:"true" json:"readinessCheckInterval"`
+	ShutdownGracePeriod         time.Duration `default:"10s" desc:"time to wait for in-flight calls to finish on shutdown, 0 means immediate stop" split_words:"true" json:"shutdownGracePeriod"`
 }
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Change - describes a changed configuration field
+type Change struct {
+	Field      string
+	Old        string
+	New        string
+	Reloadable bool
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Field, c.Old, c.New)
+}
+
+// Diff - returns the list of fields that differ between oldCfg and newCfg
+func Diff(oldCfg, newCfg *Config) []Change {
+	var changes []Change
+	oldValue, newValue := reflect.ValueOf(oldCfg).Elem(), reflect.ValueOf(newCfg).Elem()
+	for i := 0; i < oldValue.NumField(); i++ {
+		field := oldValue.Type().Field(i)
+		if reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			continue
+		}
+		changes = append(changes, Change{
+			Field:      field.Name,
+			Old:        formatValue(oldValue.Field(i)),
+			New:        formatValue(newValue.Field(i)),
+			Reloadable: field.Tag.Get("reload") == "true",
+		})
+	}
+	return changes
+}
+
+// WithReloadable - returns a copy of cfg with reloadable fields taken from update
+func (c *Config) WithReloadable(update *Config) *Config {
+	rv := *c
+	value, updateValue := reflect.ValueOf(&rv).Elem(), reflect.ValueOf(update).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).Tag.Get("reload") == "true" {
+			value.Field(i).Set(updateValue.Field(i))
+		}
+	}
+	return &rv
+}
+
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Slice {
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatValue(v.Index(i))
+		}
+		return "[" + strings.Join(items, ",") + "]"
+	}
+	if v.CanAddr() {
+		if s, ok := v.Addr().Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// keysFormat is an envconfig usage template printing "<field name> <environment variable>" pairs.
+const keysFormat = `{{range .}}{{.Name}} {{usage_key .}}
+{{end}}`
+
+// Load - reads configuration for the given environment prefix.
+//
+// Values are applied with the following precedence: defaults < config file < environment.
+// The config file is taken from the ConfigFile field (e.g. NSM_CONFIG_FILE) and may be either YAML or JSON,
+// keys are matched against the json tags of Config. Unknown keys are rejected.
+func Load(prefix string) (*Config, error) {
+	cfg := &Config{}
+	if err := envconfig.Process(prefix, cfg); err != nil {
+		return nil, errors.Wrap(err, "error processing cfg from env")
+	}
+	if cfg.ConfigFile != "" {
+		if err := loadFile(prefix, cfg); err != nil {
+			return nil, err
+		}
+	}
+	normalize(cfg)
+	return cfg, nil
+}
+
+func loadFile(prefix string, cfg *Config) error {
+	envKeys, err := envKeys(prefix, cfg)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filepath.Clean(cfg.ConfigFile))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read config file %s", cfg.ConfigFile)
+	}
+	return errors.Wrapf(applyFile(cfg, data, envKeys), "failed to apply config file %s", cfg.ConfigFile)
+}
+
+// normalize - normalizes ListenOn addresses
+func normalize(cfg *Config) {
+	for i := range cfg.ListenOn {
+		u := &cfg.ListenOn[i]
+
+		if u.Scheme != "tcp" {
+			continue
+		}
+
+		host := u.Hostname()
+		port := u.Port()
+		if port == "" {
+			continue
+		}
+
+		// If this is a literal IPv6 address, net.Listen requires brackets
+		if ip := net.ParseIP(host); ip != nil && ip.To4() == nil {
+			u.Host = net.JoinHostPort(host, port)
+		}
+	}
+}
+
+// envKeys returns environment variable names for the Config fields.
+func envKeys(prefix string, cfg *Config) (map[string]string, error) {
+	var buf bytes.Buffer
+	if err := envconfig.Usagef(prefix, cfg, &buf, keysFormat); err != nil {
+		return nil, errors.Wrap(err, "failed to get environment variable names")
+	}
+	keys := make(map[string]string)
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		if name, key, ok := strings.Cut(scanner.Text(), " "); ok {
+			keys[name] = key
+		}
+	}
+	return keys, nil
+}
+
+func applyFile(cfg *Config, data []byte, envKeys map[string]string) error {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse config file")
+	}
+	values := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return errors.Wrap(err, "config file must contain a map of values")
+	}
+
+	v := reflect.ValueOf(cfg).Elem()
+	fields := make(map[string]int)
+	for i := 0; i < v.NumField(); i++ {
+		if name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ","); name != "" && name != "-" {
+			fields[name] = i
+		}
+	}
+
+	var unknown []string
+	for key := range values {
+		if _, ok := fields[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return errors.Errorf("unknown keys: %s", strings.Join(unknown, ", "))
+	}
+
+	for key, value := range values {
+		field := v.Type().Field(fields[key])
+		if _, ok := os.LookupEnv(envKeys[field.Name]); ok {
+			continue
+		}
+		if err := setValue(v.Field(fields[key]), value); err != nil {
+			return errors.Wrapf(err, "invalid value for %s", key)
+		}
+	}
+	return nil
+}
+
+func setValue(field reflect.Value, value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	case []interface{}:
+		if field.Kind() != reflect.Slice {
+			return errors.Errorf("list is not expected for %s", field.Type())
+		}
+		slice := reflect.MakeSlice(field.Type(), len(value), len(value))
+		for i, item := range value {
+			if err := setValue(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	case map[string]interface{}:
+		if field.Kind() != reflect.Map {
+			return errors.Errorf("map is not expected for %s", field.Type())
+		}
+		m := reflect.MakeMapWithSize(field.Type(), len(value))
+		for k, item := range value {
+			key := reflect.New(field.Type().Key()).Elem()
+			if err := setString(key, k); err != nil {
+				return err
+			}
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setValue(elem, item); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, elem)
+		}
+		field.Set(m)
+		return nil
+	default:
+		return setString(field, fmt.Sprint(value))
+	}
+}
+
+// setString decodes value the same way envconfig does for environment variables.
+func setString(field reflect.Value, value string) error {
+	switch target := field.Addr().Interface().(type) {
+	case *time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		*target = d
+		return nil
+	case encoding.TextUnmarshaler:
+		return errors.WithStack(target.UnmarshalText([]byte(value)))
+	case encoding.BinaryUnmarshaler:
+		return errors.WithStack(target.UnmarshalBinary([]byte(value)))
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 0, field.Type().Bits())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 0, field.Type().Bits())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		var items []interface{}
+		for _, item := range strings.Split(value, ",") {
+			items = append(items, item)
+		}
+		return setValue(field, items)
+	case reflect.Map:
+		pairs := make(map[string]interface{})
+		for _, pair := range strings.Split(value, ",") {
+			k, v, ok := strings.Cut(pair, ":")
+			if !ok {
+				return errors.Errorf("invalid map item: %q", pair)
+			}
+			pairs[k] = v
+		}
+		return setValue(field, pairs)
+	default:
+		return errors.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
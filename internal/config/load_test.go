@@ -0,0 +1,88 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	p := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	return p
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	t.Setenv("NSM_CONFIG_FILE", writeConfigFile(t, "nsmgr.yaml", `
+name: from-file
+listenOn:
+  - unix:///tmp/nsm.io.sock
+  - tcp://:5001
+dialTimeout: 2s
+registryServerPolicies: [a.rego, b.rego]
+logLevel: DEBUG
+`))
+	t.Setenv("NSM_LOG_LEVEL", "TRACE")
+
+	cfg, err := config.Load("nsm")
+	require.NoError(t, err)
+
+	// file overrides defaults
+	require.Equal(t, "from-file", cfg.Name)
+	require.Len(t, cfg.ListenOn, 2)
+	require.Equal(t, "unix:///tmp/nsm.io.sock", cfg.ListenOn[0].String())
+	require.Equal(t, "tcp", cfg.ListenOn[1].Scheme)
+	require.Equal(t, 2*time.Second, cfg.DialTimeout)
+	require.Equal(t, []string{"a.rego", "b.rego"}, cfg.RegistryServerPolicies)
+	// environment overrides file
+	require.Equal(t, "TRACE", cfg.LogLevel)
+	// defaults are kept for the rest
+	require.Equal(t, "forwarder", cfg.ForwarderNetworkServiceName)
+	require.Equal(t, 10*time.Minute, cfg.MaxTokenLifetime)
+}
+
+func TestLoad_JSON(t *testing.T) {
+	t.Setenv("NSM_CONFIG_FILE", writeConfigFile(t, "nsmgr.json", `{"registryURL": "tcp://registry:5002", "pprofEnabled": true}`))
+
+	cfg, err := config.Load("nsm")
+	require.NoError(t, err)
+	require.Equal(t, "tcp://registry:5002", cfg.RegistryURL.String())
+	require.True(t, cfg.PprofEnabled)
+}
+
+func TestLoad_UnknownKeys(t *testing.T) {
+	t.Setenv("NSM_CONFIG_FILE", writeConfigFile(t, "nsmgr.yaml", "name: nsmgr\nlistenon: tcp://:5001\n"))
+
+	_, err := config.Load("nsm")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown keys: listenon")
+}
+
+func TestLoad_InvalidValue(t *testing.T) {
+	t.Setenv("NSM_CONFIG_FILE", writeConfigFile(t, "nsmgr.yaml", "dialTimeout: fast\n"))
+
+	_, err := config.Load("nsm")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dialTimeout")
+}
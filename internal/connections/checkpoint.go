@@ -0,0 +1,242 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// checkpointDelay - changes made within the delay are written to the checkpoint file at once
+const checkpointDelay = 100 * time.Millisecond
+
+// checkpoint - file the connections are saved to
+type checkpoint struct {
+	path    string
+	logger  log.Logger
+	changed chan struct{}
+	writeMu sync.Mutex
+}
+
+// checkpointRecord - saved connection with the SPIFFE ID of the client
+type checkpointRecord struct {
+	SpiffeID   string          `json:"spiffeID,omitempty"`
+	Connection json.RawMessage `json:"connection"`
+}
+
+// pendingConnection - restored connection waiting for the client refresh
+type pendingConnection struct {
+	conn     *networkservice.Connection
+	spiffeID string
+}
+
+// Restore - reads the connections saved before restart and passes them to readopt with the client SPIFFE IDs.
+// Connections are stored again only if readopt requests them through the chain successfully, the others are dropped.
+// Connections with mechanisms passing file descriptors (inode:// URLs) can't be requested again without the client,
+// they are kept in the checkpoint until the client refreshes them or their path expires.
+func (s *Store) Restore(ctx context.Context, readopt func(ctx context.Context, conn *networkservice.Connection, spiffeID string) error) error {
+	if s.checkpoint == nil {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Clean(s.checkpoint.path))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", s.checkpoint.path)
+	}
+	var records []*checkpointRecord
+	if err = json.Unmarshal(data, &records); err != nil {
+		return errors.Wrapf(err, "failed to parse %s", s.checkpoint.path)
+	}
+
+	for _, r := range records {
+		if ctx.Err() != nil {
+			break
+		}
+		conn := new(networkservice.Connection)
+		if err := protojson.Unmarshal(r.Connection, conn); err != nil {
+			s.checkpoint.logger.Warnf("Connection is not restored: %v", err)
+			continue
+		}
+		s.mu.RLock()
+		_, refreshed := s.connections[conn.GetId()]
+		s.mu.RUnlock()
+		if refreshed {
+			// Already refreshed by the client
+			continue
+		}
+		if passesFiles(conn) {
+			s.keepPending(conn, r.SpiffeID)
+			continue
+		}
+		// Re-adopting request doesn't come from the client, so the SPIFFE ID is kept
+		s.mu.Lock()
+		s.spiffeIDs[conn.GetId()] = r.SpiffeID
+		s.mu.Unlock()
+
+		if err := readopt(ctx, conn, r.SpiffeID); err != nil {
+			s.checkpoint.logger.Warnf("Connection %s is not restored: %v", conn.GetId(), err)
+			continue
+		}
+		s.checkpoint.logger.Infof("Connection %s to %s is restored", conn.GetId(), conn.GetNetworkService())
+	}
+
+	s.mu.Lock()
+	for id := range s.spiffeIDs {
+		if _, ok := s.connections[id]; !ok {
+			delete(s.spiffeIDs, id)
+		}
+	}
+	s.mu.Unlock()
+	s.Flush()
+	return nil
+}
+
+// keepPending - keeps the connection in the checkpoint until the client refreshes it or its path expires
+func (s *Store) keepPending(conn *networkservice.Connection, spiffeID string) {
+	if !expires(conn).After(time.Now()) {
+		s.checkpoint.logger.Infof("Connection %s is not restored: path is expired", conn.GetId())
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.connections[conn.GetId()]; ok {
+		// Already refreshed by the client
+		return
+	}
+	s.pending[conn.GetId()] = &pendingConnection{conn: conn, spiffeID: spiffeID}
+	s.checkpoint.logger.Infof("Connection %s to %s passes file descriptors, it is restored on the client refresh",
+		conn.GetId(), conn.GetNetworkService())
+}
+
+// passesFiles - returns true if the connection mechanism refers to a file descriptor passed by the client, it can't
+// be resolved without the client connection
+func passesFiles(conn *networkservice.Connection) bool {
+	u, err := url.Parse(conn.GetMechanism().GetParameters()[common.InodeURL])
+	return err == nil && u.Scheme == "inode"
+}
+
+// expires - returns expiration time of the nsmgr path segment of the connection
+func expires(conn *networkservice.Connection) time.Time {
+	segments := conn.GetPath().GetPathSegments()
+	index := int(conn.GetPath().GetIndex())
+	if index >= len(segments) || segments[index].GetExpires() == nil {
+		return time.Time{}
+	}
+	return segments[index].GetExpires().AsTime()
+}
+
+// runCheckpoint - writes the changes to the checkpoint file in the background until ctx is done
+func (s *Store) runCheckpoint(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			s.Flush()
+			return
+		case <-s.checkpoint.changed:
+		}
+		timer := time.NewTimer(checkpointDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+		s.Flush()
+	}
+}
+
+// changed - schedules writing of the checkpoint file, s.mu must be held
+func (s *Store) changed() {
+	if s.checkpoint == nil {
+		return
+	}
+	select {
+	case s.checkpoint.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Flush - writes the connections to the checkpoint file now, the file is replaced atomically
+func (s *Store) Flush() {
+	if s.checkpoint == nil {
+		return
+	}
+	s.checkpoint.writeMu.Lock()
+	defer s.checkpoint.writeMu.Unlock()
+
+	data, err := s.marshal()
+	if err != nil {
+		s.checkpoint.logger.Errorf("Failed to save connections: %v", err)
+		return
+	}
+	tmp := s.checkpoint.path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o600); err == nil {
+		err = os.Rename(tmp, s.checkpoint.path)
+	}
+	if err != nil {
+		s.checkpoint.logger.Errorf("Failed to save connections to %s: %v", s.checkpoint.path, err)
+	}
+}
+
+// marshal - returns the active and not expired pending connections sorted by ID
+func (s *Store) marshal() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	ids := make([]string, 0, len(s.connections)+len(s.pending))
+	for id := range s.connections {
+		ids = append(ids, id)
+	}
+	for id, p := range s.pending {
+		if !expires(p.conn).After(now) {
+			delete(s.pending, id)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	records := make([]*checkpointRecord, 0, len(ids))
+	for _, id := range ids {
+		conn, spiffeID := s.connections[id], s.spiffeIDs[id]
+		if p, ok := s.pending[id]; ok {
+			conn, spiffeID = p.conn, p.spiffeID
+		}
+		data, err := protojson.Marshal(conn)
+		if err != nil {
+			s.checkpoint.logger.Errorf("Failed to save connection %s: %v", id, err)
+			continue
+		}
+		records = append(records, &checkpointRecord{SpiffeID: spiffeID, Connection: data})
+	}
+	return json.Marshal(records)
+}
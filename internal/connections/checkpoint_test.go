@@ -0,0 +1,163 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/connections"
+)
+
+func withPeerSpiffeID(ctx context.Context, spiffeID string) context.Context {
+	u, _ := url.Parse(spiffeID)
+	return peer.NewContext(ctx, &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{u}}}}},
+	})
+}
+
+type restored struct {
+	connections map[string]*networkservice.Connection
+	spiffeIDs   map[string]string
+}
+
+func restore(t *testing.T, path string, failed ...string) (*connections.Store, *restored) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	store := connections.NewStore(connections.WithCheckpoint(ctx, path, log.L()))
+	server := chain.NewNetworkServiceServer(store.NetworkServiceServer())
+	rv := &restored{connections: make(map[string]*networkservice.Connection), spiffeIDs: make(map[string]string)}
+	require.NoError(t, store.Restore(context.Background(), func(ctx context.Context, conn *networkservice.Connection, spiffeID string) error {
+		for _, id := range failed {
+			if conn.GetId() == id {
+				return errors.New("forwarder is not available")
+			}
+		}
+		rv.connections[conn.GetId()], rv.spiffeIDs[conn.GetId()] = conn, spiffeID
+		_, err := server.Request(ctx, &networkservice.NetworkServiceRequest{Connection: conn})
+		return err
+	}))
+	return store, rv
+}
+
+func TestStore_Checkpoint(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "connections.json")
+	store := connections.NewStore(connections.WithCheckpoint(ctx, path, log.L()))
+	server := chain.NewNetworkServiceServer(store.NetworkServiceServer())
+
+	_, err := server.Request(withPeerSpiffeID(ctx, "spiffe://example.org/client"), request("1", "ns-a", "c1"))
+	require.NoError(t, err)
+	// Refresh without peer keeps SPIFFE ID
+	_, err = server.Request(ctx, request("1", "ns-a", "c1"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, request("2", "ns-b", "c2"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, request("3", "ns-a", "c3"))
+	require.NoError(t, err)
+	_, err = server.Close(ctx, request("3", "ns-a", "c3").GetConnection())
+	require.NoError(t, err)
+	store.Flush()
+
+	// Connection 2 can't be re-adopted and is dropped from the checkpoint
+	restoredStore, r := restore(t, path, "2")
+	require.Len(t, r.connections, 1)
+	conn := r.connections["1"]
+	require.NotNil(t, conn)
+	require.Equal(t, "ns-a-nse", conn.GetNetworkServiceEndpointName())
+	require.Equal(t, "forwarder", conn.GetPath().GetPathSegments()[2].GetName())
+	require.Equal(t, "spiffe://example.org/client", r.spiffeIDs["1"])
+	require.Len(t, restoredStore.List(), 1)
+
+	_, r = restore(t, path)
+	require.Len(t, r.connections, 1)
+	require.Equal(t, "spiffe://example.org/client", r.spiffeIDs["1"])
+}
+
+func TestStore_CheckpointInBackground(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "connections.json")
+	store := connections.NewStore(connections.WithCheckpoint(ctx, path, log.L()))
+	server := chain.NewNetworkServiceServer(store.NetworkServiceServer())
+
+	_, err := server.Request(ctx, request("1", "ns-a", "c1"))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		data, readErr := os.ReadFile(filepath.Clean(path))
+		return readErr == nil && strings.Contains(string(data), `"ns-a"`)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestStore_RestorePending(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "connections.json")
+	store := connections.NewStore(connections.WithCheckpoint(ctx, path, log.L()))
+	server := chain.NewNetworkServiceServer(store.NetworkServiceServer())
+
+	// Kernel mechanism refers to the client network namespace passed by file descriptor
+	r := request("1", "ns-a", "c1")
+	r.GetConnection().GetMechanism().Parameters = map[string]string{common.InodeURL: "inode://4/4026531992"}
+	r.GetConnection().GetPath().GetPathSegments()[1].Expires = timestamppb.New(time.Now().Add(time.Minute))
+	_, err := server.Request(withPeerSpiffeID(ctx, "spiffe://example.org/client"), r)
+	require.NoError(t, err)
+	_, err = server.Request(ctx, request("2", "ns-b", "c2"))
+	require.NoError(t, err)
+	store.Flush()
+
+	// Connection 1 is not requested again, it is kept in the checkpoint until the client refresh
+	restoredStore, restoredConns := restore(t, path)
+	require.Len(t, restoredConns.connections, 1)
+	require.NotNil(t, restoredConns.connections["2"])
+	require.Len(t, restoredStore.List(), 1)
+
+	_, restoredConns = restore(t, path)
+	require.Len(t, restoredConns.connections, 1)
+
+	restoredStore, _ = restore(t, path)
+	_, err = chain.NewNetworkServiceServer(restoredStore.NetworkServiceServer()).Request(ctx, r)
+	require.NoError(t, err)
+	require.Len(t, restoredStore.List(), 2)
+	restoredStore.Flush()
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"spiffeID":"spiffe://example.org/client"`)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/admin"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+)
+
+// PathSegment - path segment of the connection
+type PathSegment struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+// Info - connection as reported by the admin endpoint
+type Info struct {
+	ID             string         `json:"id"`
+	NetworkService string         `json:"networkService"`
+	Endpoint       string         `json:"endpoint"`
+	Forwarder      string         `json:"forwarder"`
+	Mechanism      string         `json:"mechanism"`
+	SpiffeID       string         `json:"spiffeID,omitempty"`
+	Peer           *peercred.Cred `json:"peer,omitempty"`
+	Path           []PathSegment  `json:"path"`
+}
+
+// Handler - returns HTTP handler listing the connections of the store. Client SPIFFE IDs are taken from
+// spiffeIDConnMap filled by the authorize server.
+// Query parameters:
+//   - service - show only connections to the network service
+//   - spiffe_id - show only connections of the client with the SPIFFE ID
+//   - format - json (default) or table
+func Handler(store *Store, spiffeIDConnMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		service := r.URL.Query().Get("service")
+		spiffeID := r.URL.Query().Get("spiffe_id")
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "table" {
+			http.Error(w, fmt.Sprintf("unknown format %q, expected json or table", format), http.StatusBadRequest)
+			return
+		}
+
+		spiffeIDs := spiffeIDsByConnID(spiffeIDConnMap)
+		infos := []*Info{}
+		for _, conn := range store.List() {
+			info := newInfo(conn, spiffeIDs)
+			info.Peer, _ = store.Peer(conn.GetId())
+			if service != "" && info.NetworkService != service {
+				continue
+			}
+			if spiffeID != "" && info.SpiffeID != spiffeID {
+				continue
+			}
+			infos = append(infos, info)
+		}
+
+		if format == "table" {
+			writeTable(w, infos)
+			return
+		}
+		admin.WriteJSON(w, infos)
+	}
+}
+
+// spiffeIDsByConnID - reverses spiffeIDConnMap, it is keyed by the connection ID of the previous path segment
+func spiffeIDsByConnID(spiffeIDConnMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]) map[string]string {
+	result := make(map[string]string)
+	spiffeIDConnMap.Range(func(id spiffeid.ID, connIDs *genericsync.Map[string, struct{}]) bool {
+		connIDs.Range(func(connID string, _ struct{}) bool {
+			result[connID] = id.String()
+			return true
+		})
+		return true
+	})
+	return result
+}
+
+func newInfo(conn *networkservice.Connection, spiffeIDs map[string]string) *Info {
+	info := &Info{
+		ID:             conn.GetId(),
+		NetworkService: conn.GetNetworkService(),
+		Endpoint:       conn.GetNetworkServiceEndpointName(),
+		Mechanism:      conn.GetMechanism().GetType(),
+	}
+	segments := conn.GetPath().GetPathSegments()
+	for _, segment := range segments {
+		info.Path = append(info.Path, PathSegment{Name: segment.GetName(), ID: segment.GetId()})
+	}
+	// Path index points to nsmgr, the previous segment is the client and the next one is the forwarder
+	index := int(conn.GetPath().GetIndex())
+	if index > 0 && index <= len(segments) {
+		info.SpiffeID = spiffeIDs[segments[index-1].GetId()]
+	}
+	if index+1 < len(segments) {
+		info.Forwarder = segments[index+1].GetName()
+	}
+	return info
+}
+
+func writeTable(w http.ResponseWriter, infos []*Info) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tNETWORK SERVICE\tENDPOINT\tFORWARDER\tMECHANISM\tSPIFFE ID\tPEER\tPATH")
+	for _, info := range infos {
+		var path []string
+		for _, segment := range info.Path {
+			path = append(path, segment.Name)
+		}
+		var peer string
+		if info.Peer != nil {
+			peer = info.Peer.String()
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			info.ID, info.NetworkService, info.Endpoint, info.Forwarder, info.Mechanism, info.SpiffeID, peer, strings.Join(path, " -> "))
+	}
+	_ = tw.Flush()
+}
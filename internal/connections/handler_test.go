@@ -0,0 +1,106 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/connections"
+)
+
+func request(id, networkService, clientConnID string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:                         id,
+			NetworkService:             networkService,
+			NetworkServiceEndpointName: networkService + "-nse",
+			Mechanism:                  &networkservice.Mechanism{Type: kernel.MECHANISM},
+			Path: &networkservice.Path{
+				Index: 1,
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "client", Id: clientConnID},
+					{Name: "nsmgr", Id: id},
+					{Name: "forwarder", Id: id + "-fwd"},
+					{Name: networkService + "-nse", Id: id + "-nse"},
+				},
+			},
+		},
+	}
+}
+
+func get(t *testing.T, handler http.Handler, query string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/connections"+query, http.NoBody))
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	store := connections.NewStore()
+	server := chain.NewNetworkServiceServer(store.NetworkServiceServer())
+	for _, r := range []*networkservice.NetworkServiceRequest{request("1", "ns-a", "c1"), request("2", "ns-b", "c2"), request("3", "ns-a", "c3")} {
+		_, err := server.Request(context.Background(), r)
+		require.NoError(t, err)
+	}
+	_, err := server.Close(context.Background(), request("3", "ns-a", "c3").GetConnection())
+	require.NoError(t, err)
+
+	spiffeIDConnMap := genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]{}
+	ids := new(genericsync.Map[string, struct{}])
+	ids.Store("c2", struct{}{})
+	spiffeIDConnMap.Store(spiffeid.RequireFromString("spiffe://example.org/client"), ids)
+
+	handler := connections.Handler(store, &spiffeIDConnMap)
+
+	var infos []*connections.Info
+	require.NoError(t, json.Unmarshal(get(t, handler, "").Body.Bytes(), &infos))
+	require.Len(t, infos, 2)
+	require.Equal(t, "1", infos[0].ID)
+	require.Equal(t, "ns-a", infos[0].NetworkService)
+	require.Equal(t, "ns-a-nse", infos[0].Endpoint)
+	require.Equal(t, "forwarder", infos[0].Forwarder)
+	require.Equal(t, kernel.MECHANISM, infos[0].Mechanism)
+	require.Len(t, infos[0].Path, 4)
+	require.Empty(t, infos[0].SpiffeID)
+	require.Equal(t, "spiffe://example.org/client", infos[1].SpiffeID)
+
+	require.NoError(t, json.Unmarshal(get(t, handler, "?service=ns-b").Body.Bytes(), &infos))
+	require.Len(t, infos, 1)
+	require.Equal(t, "2", infos[0].ID)
+
+	require.NoError(t, json.Unmarshal(get(t, handler, "?spiffe_id=spiffe://example.org/client").Body.Bytes(), &infos))
+	require.Len(t, infos, 1)
+	require.Equal(t, "2", infos[0].ID)
+
+	table := get(t, handler, "?format=table&service=ns-a").Body.String()
+	require.Contains(t, table, "NETWORK SERVICE")
+	require.Contains(t, table, "client -> nsmgr -> forwarder -> ns-a-nse")
+	require.NotContains(t, table, "ns-b")
+
+	require.Equal(t, http.StatusBadRequest, get(t, handler, "?format=yaml").Code)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connections keeps track of the connections brokered by nsmgr and provides admin endpoint listing them
+package connections
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/spire"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+)
+
+// Store - active connections of nsmgr
+type Store struct {
+	mu          sync.RWMutex
+	connections map[string]*networkservice.Connection
+	spiffeIDs   map[string]string
+	peers       map[string]*peercred.Cred
+	pending     map[string]*pendingConnection
+	checkpoint  *checkpoint
+}
+
+// Option - option for NewStore
+type Option func(s *Store)
+
+// WithCheckpoint - saves the connections with the client SPIFFE IDs to the file in the background after every change
+// until ctx is done, see Restore and Flush
+func WithCheckpoint(ctx context.Context, path string, logger log.Logger) Option {
+	return func(s *Store) {
+		s.checkpoint = &checkpoint{path: path, logger: logger, changed: make(chan struct{}, 1)}
+		go s.runCheckpoint(ctx)
+	}
+}
+
+// NewStore - creates Store
+func NewStore(opts ...Option) *Store {
+	s := &Store{
+		connections: make(map[string]*networkservice.Connection),
+		spiffeIDs:   make(map[string]string),
+		peers:       make(map[string]*peercred.Cred),
+		pending:     make(map[string]*pendingConnection),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NetworkServiceServer - returns chain element storing the connections. It should be placed after begin,
+// so Close on connection expiration is handled as well.
+func (s *Store) NetworkServiceServer() networkservice.NetworkServiceServer {
+	return &storeServer{store: s}
+}
+
+// List - returns copies of the active connections sorted by ID
+func (s *Store) List() []*networkservice.Connection {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*networkservice.Connection, 0, len(s.connections))
+	for _, conn := range s.connections {
+		result = append(result, conn.Clone())
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].GetId() < result[j].GetId() })
+	return result
+}
+
+// Peer - returns credentials of the local client process of the connection if it is connected over a unix socket
+func (s *Store) Peer(id string) (*peercred.Cred, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cred, ok := s.peers[id]
+	return cred, ok
+}
+
+// store - stores the connection, spiffeID and peer are kept from the previous request if they are not known. Peer
+// credentials are not saved to the checkpoint, the client process can be different after restart.
+func (s *Store) store(conn *networkservice.Connection, spiffeID string, peer *peercred.Cred) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connections[conn.GetId()] = conn.Clone()
+	if p, ok := s.pending[conn.GetId()]; ok {
+		s.spiffeIDs[conn.GetId()] = p.spiffeID
+		delete(s.pending, conn.GetId())
+	}
+	if spiffeID != "" {
+		s.spiffeIDs[conn.GetId()] = spiffeID
+	}
+	if peer != nil {
+		s.peers[conn.GetId()] = peer
+	}
+	s.changed()
+}
+
+func (s *Store) delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, active := s.connections[id]
+	_, pending := s.pending[id]
+	if !active && !pending {
+		return
+	}
+	delete(s.connections, id)
+	delete(s.spiffeIDs, id)
+	delete(s.peers, id)
+	delete(s.pending, id)
+	s.changed()
+}
+
+type storeServer struct {
+	store *Store
+}
+
+func (s *storeServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err == nil {
+		var spiffeID string
+		if id, idErr := spire.PeerSpiffeIDFromContext(ctx); idErr == nil {
+			spiffeID = id.String()
+		}
+		peer, _ := peercred.FromContext(ctx)
+		s.store.store(conn, spiffeID, peer)
+	}
+	return conn, err
+}
+
+func (s *storeServer) Close(ctx context.Context, conn *networkservice.Connection) (*emptypb.Empty, error) {
+	s.store.delete(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
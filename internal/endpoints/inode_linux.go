@@ -0,0 +1,104 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package endpoints
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/edwarnicke/grpcfd"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+)
+
+// recvTimeout - time to wait for the passed file descriptor, it is sent together with the registration
+const recvTimeout = time.Second
+
+// resolveInode - returns unix URL of the socket passed by file descriptor as inodeURL. The path is taken from the
+// received file descriptor and is looked up in the root of the peer process as well, since NSE may run in another
+// mount namespace. The path is accepted only if it is the same socket.
+func resolveInode(ctx context.Context, inodeURL string) (string, error) {
+	transceiver, ok := grpcfd.FromContext(ctx)
+	if !ok {
+		return "", errors.Errorf("socket %s is not passed over unix socket connection", inodeURL)
+	}
+	fileCh, err := transceiver.RecvFileByURL(inodeURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid url %s", inodeURL)
+	}
+
+	recvCtx, cancel := context.WithTimeout(ctx, recvTimeout)
+	defer cancel()
+	var file *os.File
+	select {
+	case file = <-fileCh:
+	case <-recvCtx.Done():
+		go func() {
+			for f := range fileCh {
+				_ = f.Close()
+			}
+		}()
+		return "", errors.Wrapf(recvCtx.Err(), "file descriptor of socket %s is not received", inodeURL)
+	}
+	if file == nil {
+		return "", errors.Errorf("file descriptor of socket %s is not received", inodeURL)
+	}
+	defer func() { _ = file.Close() }()
+
+	path, err := os.Readlink(file.Name())
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get path of socket %s", inodeURL)
+	}
+	candidates := []string{path}
+	if cred, ok := peercred.FromContext(ctx); ok {
+		candidates = append(candidates, filepath.Join("/proc", strconv.Itoa(int(cred.PID)), "root", path))
+	}
+	for _, candidate := range candidates {
+		if checkInode(candidate, inodeURL) == nil {
+			return (&url.URL{Scheme: "unix", Path: candidate}).String(), nil
+		}
+	}
+	return "", errors.Errorf("socket %s passed as %s is not reachable by path from nsmgr", path, inodeURL)
+}
+
+// checkInode - checks that socketPath is the socket passed as inodeURL, socketPath may also be unix:// URL
+func checkInode(socketPath, inodeURL string) error {
+	if u, err := url.Parse(socketPath); err == nil && u.Scheme == "unix" {
+		socketPath = u.Path
+	}
+	dev, ino, err := grpcfd.URLStringToDevIno(inodeURL)
+	if err != nil {
+		return errors.Wrapf(err, "invalid url %s", inodeURL)
+	}
+	var stat syscall.Stat_t
+	if err := syscall.Stat(socketPath, &stat); err != nil {
+		return errors.Wrapf(err, "failed to stat %s", socketPath)
+	}
+	if uint64(stat.Dev) != dev || stat.Ino != ino {
+		return errors.Errorf("%s is not the socket %s", socketPath, inodeURL)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+package endpoints
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// resolveInode - sockets are passed by file descriptor only on linux
+func resolveInode(_ context.Context, inodeURL string) (string, error) {
+	return "", errors.Errorf("socket %s passed by file descriptor is not supported on this platform", inodeURL)
+}
+
+// checkInode - sockets are passed by file descriptor only on linux
+func checkInode(_, inodeURL string) error {
+	return errors.Errorf("socket %s passed by file descriptor is not supported on this platform", inodeURL)
+}
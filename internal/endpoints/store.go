@@ -0,0 +1,363 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package endpoints persists NSEs and forwarders registered on nsmgr, so they can be restored after restart
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// saveDelay - changes made within the delay are written to the file at once
+const saveDelay = 100 * time.Millisecond
+
+// Record - persisted registration of a local NSE or forwarder
+type Record struct {
+	Name                string                       `json:"name"`
+	URL                 string                       `json:"url"`
+	NetworkServiceNames []string                     `json:"networkServiceNames,omitempty"`
+	Labels              map[string]map[string]string `json:"labels,omitempty"`
+	ExpirationTime      time.Time                    `json:"expirationTime"`
+	PathIDs             []string                     `json:"pathIds,omitempty"`
+	// InodeURL - inode:// URL of the socket passed by file descriptor, URL is the path of the same socket
+	InodeURL string `json:"inodeUrl,omitempty"`
+}
+
+func newRecord(nse *registry.NetworkServiceEndpoint, inodeURL string) *Record {
+	r := &Record{
+		Name:                nse.GetName(),
+		URL:                 nse.GetUrl(),
+		NetworkServiceNames: nse.GetNetworkServiceNames(),
+		ExpirationTime:      nse.GetExpirationTime().AsTime(),
+		PathIDs:             nse.GetPathIds(),
+		InodeURL:            inodeURL,
+	}
+	for service, labels := range nse.GetNetworkServiceLabels() {
+		if r.Labels == nil {
+			r.Labels = make(map[string]map[string]string)
+		}
+		r.Labels[service] = labels.GetLabels()
+	}
+	return r
+}
+
+// NSE - returns the registration of the record
+func (r *Record) NSE() *registry.NetworkServiceEndpoint {
+	nse := &registry.NetworkServiceEndpoint{
+		Name:                r.Name,
+		Url:                 r.URL,
+		NetworkServiceNames: r.NetworkServiceNames,
+		ExpirationTime:      timestamppb.New(r.ExpirationTime),
+	}
+	for service, labels := range r.Labels {
+		if nse.NetworkServiceLabels == nil {
+			nse.NetworkServiceLabels = make(map[string]*registry.NetworkServiceLabels)
+		}
+		nse.NetworkServiceLabels[service] = &registry.NetworkServiceLabels{Labels: labels}
+	}
+	return nse
+}
+
+// Store - local registrations of nsmgr saved to a file
+type Store struct {
+	path       string
+	logger     log.Logger
+	mu         sync.Mutex
+	records    map[string]*Record
+	unresolved map[string]struct{}
+	changes    chan struct{}
+	writeMu    sync.Mutex
+}
+
+// NewStore - creates Store saving the registrations to path in the background after every change until ctx is done,
+// see Flush
+func NewStore(ctx context.Context, path string, logger log.Logger) *Store {
+	s := &Store{
+		path:       path,
+		logger:     logger,
+		records:    make(map[string]*Record),
+		unresolved: make(map[string]struct{}),
+		changes:    make(chan struct{}, 1),
+	}
+	go s.runSave(ctx)
+	return s
+}
+
+// NSEServer - returns chain element storing the registrations. It should be placed before recvfd, so the original
+// NSE URLs are stored. Registrations passing the socket by file descriptor (inode:// URLs) are stored with the path
+// of the socket found by the passed file descriptor, the ones with sockets not reachable by path from nsmgr are
+// not stored and a warning is logged.
+func (s *Store) NSEServer() registry.NetworkServiceEndpointRegistryServer {
+	return &storeServer{store: s}
+}
+
+// Load - reads the saved registrations, expired ones are skipped
+func (s *Store) Load() ([]*Record, error) {
+	data, err := os.ReadFile(filepath.Clean(s.path))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", s.path)
+	}
+	var records []*Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", s.path)
+	}
+	now := time.Now()
+	var rv []*Record
+	for _, r := range records {
+		if r.ExpirationTime.After(now) {
+			rv = append(rv, r)
+		}
+	}
+	return rv, nil
+}
+
+// Restore - loads the saved registrations and passes the ones with reachable URLs to register.
+// Registrations are stored again only if register succeeds.
+func (s *Store) Restore(ctx context.Context, dialTimeout time.Duration, register func(ctx context.Context, r *Record) error) error {
+	records, err := s.Load()
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if r.InodeURL != "" {
+			if err := checkInode(r.URL, r.InodeURL); err != nil {
+				s.logger.Infof("Registration of %s is not restored: %v", r.Name, err)
+				continue
+			}
+		}
+		if err := alive(r.URL, dialTimeout); err != nil {
+			s.logger.Infof("Registration of %s is not restored: %v", r.Name, err)
+			continue
+		}
+		if err := register(ctx, r); err != nil {
+			s.logger.Warnf("Registration of %s is not restored: %v", r.Name, err)
+			continue
+		}
+		s.logger.Infof("Registration of %s at %s is restored", r.Name, r.URL)
+	}
+
+	s.mu.Lock()
+	// Registrations are stored again with path IDs and URL of the restoring call, keep the original ones
+	for _, r := range records {
+		if stored, ok := s.records[r.Name]; ok {
+			stored.PathIDs = r.PathIDs
+			if stored.URL == r.URL {
+				stored.InodeURL = r.InodeURL
+			}
+		}
+	}
+	s.mu.Unlock()
+	// Drop the registrations which are not restored
+	s.Flush()
+	return nil
+}
+
+// restorable - returns true if the endpoint can be dialed by URL after restart
+func restorable(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "unix" || u.Scheme == "tcp"
+}
+
+// isInode - returns true for the inode:// URLs of the sockets passed by file descriptor
+func isInode(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && u.Scheme == "inode"
+}
+
+// alive - checks that somebody is listening on the endpoint URL
+func alive(rawURL string, dialTimeout time.Duration) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrapf(err, "invalid url %s", rawURL)
+	}
+	address := u.Host
+	if u.Scheme == "unix" {
+		address = u.Path
+	}
+	conn, err := net.DialTimeout(u.Scheme, address, dialTimeout)
+	if err != nil {
+		return errors.Wrapf(err, "%s is not reachable", rawURL)
+	}
+	return conn.Close()
+}
+
+// resolve - returns unix URL of the socket passed by file descriptor as inodeURL or empty string if nsmgr can't reach
+// the socket by path. Refreshes of the registration reuse the URL found on the first registration.
+func (s *Store) resolve(ctx context.Context, name, inodeURL string) string {
+	s.mu.Lock()
+	if r, ok := s.records[name]; ok && r.InodeURL == inodeURL {
+		s.mu.Unlock()
+		return r.URL
+	}
+	s.mu.Unlock()
+
+	u, err := resolveInode(ctx, inodeURL)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		if _, ok := s.unresolved[name]; !ok {
+			s.unresolved[name] = struct{}{}
+			s.logger.Warnf("Registration of %s can't be restored after nsmgr restart: %v", name, err)
+		}
+		return ""
+	}
+	delete(s.unresolved, name)
+	return u
+}
+
+func (s *Store) store(nse *registry.NetworkServiceEndpoint, inodeURL string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[nse.GetName()] = newRecord(nse, inodeURL)
+	s.changed()
+}
+
+// unregister - deletes the registration and forgets it was not resolved
+func (s *Store) unregister(name string) {
+	s.mu.Lock()
+	delete(s.unresolved, name)
+	s.mu.Unlock()
+	s.delete(name)
+}
+
+func (s *Store) delete(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.records[name]; !ok {
+		return
+	}
+	delete(s.records, name)
+	s.changed()
+}
+
+// runSave - writes the changes to the file in the background until ctx is done
+func (s *Store) runSave(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			s.Flush()
+			return
+		case <-s.changes:
+		}
+		timer := time.NewTimer(saveDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+		s.Flush()
+	}
+}
+
+// changed - schedules writing of the file, s.mu must be held
+func (s *Store) changed() {
+	select {
+	case s.changes <- struct{}{}:
+	default:
+	}
+}
+
+// Flush - writes not expired records to the file now, the file is replaced atomically
+func (s *Store) Flush() {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	data, err := s.marshal()
+	if err != nil {
+		s.logger.Errorf("Failed to save local registrations: %v", err)
+		return
+	}
+	tmp := s.path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o600); err == nil {
+		err = os.Rename(tmp, s.path)
+	}
+	if err != nil {
+		s.logger.Errorf("Failed to save local registrations to %s: %v", s.path, err)
+	}
+}
+
+// marshal - returns not expired records sorted by name, expired ones are dropped
+func (s *Store) marshal() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	records := make([]*Record, 0, len(s.records))
+	for name, r := range s.records {
+		if !r.ExpirationTime.After(now) {
+			delete(s.records, name)
+			continue
+		}
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Name < records[j].Name })
+	return json.MarshalIndent(records, "", "  ")
+}
+
+type storeServer struct {
+	store *Store
+}
+
+func (s *storeServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	u, inodeURL := nse.GetUrl(), ""
+	if isInode(u) {
+		inodeURL, u = u, s.store.resolve(ctx, nse.GetName(), u)
+	}
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	if restorable(u) {
+		stored := resp.Clone()
+		stored.Url = u
+		s.store.store(stored, inodeURL)
+	} else {
+		s.store.delete(nse.GetName())
+	}
+	return resp, nil
+}
+
+func (s *storeServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *storeServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*emptypb.Empty, error) {
+	s.store.unregister(nse.GetName())
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package endpoints_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edwarnicke/grpcfd"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/sendfd"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+)
+
+func TestStore_RestoreInode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "endpoints.json")
+
+	// NSE socket
+	nseSocket := filepath.Join(dir, "nse-1.sock")
+	nseListener, err := net.Listen("unix", nseSocket)
+	require.NoError(t, err)
+	defer func() { _ = nseListener.Close() }()
+
+	// nsmgr registry server receiving the passed files
+	store := newStore(t, path)
+	server := grpc.NewServer(grpc.Creds(grpcfd.TransportCredentials(insecure.NewCredentials())))
+	registry.RegisterNetworkServiceEndpointRegistryServer(server,
+		chain.NewNetworkServiceEndpointRegistryServer(store.NSEServer(), memory.NewNetworkServiceEndpointRegistryServer()))
+	defer server.Stop()
+
+	nsmSocket := filepath.Join(dir, "nsm.sock")
+	nsmListener, err := net.Listen("unix", nsmSocket)
+	require.NoError(t, err)
+	go func() { _ = server.Serve(nsmListener) }()
+
+	// NSE registers unix URL, sendfd replaces it with inode:// URL and passes the socket
+	cc, err := grpc.NewClient("unix://"+nsmSocket, grpc.WithTransportCredentials(grpcfd.TransportCredentials(insecure.NewCredentials())))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+	client := chain.NewNetworkServiceEndpointRegistryClient(
+		sendfd.NewNetworkServiceEndpointRegistryClient(),
+		registry.NewNetworkServiceEndpointRegistryClient(cc))
+
+	for i := 0; i < 2; i++ {
+		resp, regErr := client.Register(ctx, nse("nse-1", "unix://"+nseSocket, time.Minute))
+		require.NoError(t, regErr)
+		require.Equal(t, "unix://"+nseSocket, resp.GetUrl())
+	}
+	store.Flush()
+
+	records := restored(t, newStore(t, path))
+	require.Len(t, records, 1)
+	r := records["nse-1"]
+	require.NotNil(t, r)
+	require.Equal(t, "unix://"+nseSocket, r.URL)
+	require.Regexp(t, "^inode://[0-9]+/[0-9]+$", r.InodeURL)
+
+	// Another socket at the same path is not the registered NSE
+	_, err = client.Register(ctx, nse("nse-1", "unix://"+nseSocket, time.Minute))
+	require.NoError(t, err)
+	store.Flush()
+	loaded, err := newStore(t, path).Load()
+	require.NoError(t, err)
+	require.Len(t, loaded, 1)
+	_ = nseListener.Close()
+	nseListener, err = net.Listen("unix", nseSocket)
+	require.NoError(t, err)
+	require.Empty(t, restored(t, newStore(t, path)))
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoints_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/endpoints"
+)
+
+func nse(name, u string, expires time.Duration) *registry.NetworkServiceEndpoint {
+	return &registry.NetworkServiceEndpoint{
+		Name:                name,
+		Url:                 u,
+		NetworkServiceNames: []string{"my-service"},
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			"my-service": {Labels: map[string]string{"app": name}},
+		},
+		ExpirationTime: timestamppb.New(time.Now().Add(expires)),
+		PathIds:        []string{"spiffe://example.org/" + name, "spiffe://example.org/nsmgr"},
+	}
+}
+
+func newStore(t *testing.T, path string) *endpoints.Store {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return endpoints.NewStore(ctx, path, log.L())
+}
+
+func restored(t *testing.T, store *endpoints.Store) map[string]*endpoints.Record {
+	rv := make(map[string]*endpoints.Record)
+	require.NoError(t, store.Restore(context.Background(), time.Second, func(_ context.Context, r *endpoints.Record) error {
+		rv[r.Name] = r
+		return nil
+	}))
+	return rv
+}
+
+func TestStore_Restore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "endpoints.json")
+
+	listener, err := net.Listen("unix", filepath.Join(dir, "nse-1.sock"))
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+
+	store := newStore(t, path)
+	server := chain.NewNetworkServiceEndpointRegistryServer(store.NSEServer(), memory.NewNetworkServiceEndpointRegistryServer())
+
+	ctx := context.Background()
+	for _, e := range []*registry.NetworkServiceEndpoint{
+		nse("nse-1", "unix://"+filepath.Join(dir, "nse-1.sock"), time.Minute),
+		nse("nse-2", "unix://"+filepath.Join(dir, "nse-2.sock"), time.Minute),
+		nse("nse-3", "inode://1/2", time.Minute),
+		nse("nse-4", "unix://"+filepath.Join(dir, "nse-1.sock"), -time.Minute),
+	} {
+		_, err = server.Register(ctx, e)
+		require.NoError(t, err)
+	}
+	store.Flush()
+
+	// Only nse-1 is listening, nse-3 passed the file descriptor and nse-4 is expired
+	records := restored(t, newStore(t, path))
+	require.Len(t, records, 1)
+	r := records["nse-1"]
+	require.NotNil(t, r)
+	require.Equal(t, []string{"my-service"}, r.NetworkServiceNames)
+	require.Equal(t, map[string]string{"app": "nse-1"}, r.Labels["my-service"])
+	require.Equal(t, []string{"spiffe://example.org/nse-1", "spiffe://example.org/nsmgr"}, r.PathIDs)
+	require.Equal(t, "unix://"+filepath.Join(dir, "nse-1.sock"), r.NSE().GetUrl())
+
+	_, err = server.Unregister(ctx, nse("nse-1", "unix://"+filepath.Join(dir, "nse-1.sock"), time.Minute))
+	require.NoError(t, err)
+	store.Flush()
+	require.Empty(t, restored(t, newStore(t, path)))
+}
+
+func TestStore_SaveInBackground(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "endpoints.json")
+
+	store := newStore(t, path)
+	server := chain.NewNetworkServiceEndpointRegistryServer(store.NSEServer(), memory.NewNetworkServiceEndpointRegistryServer())
+
+	_, err := server.Register(context.Background(), nse("nse-1", "unix://"+filepath.Join(dir, "nse-1.sock"), time.Minute))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		data, readErr := os.ReadFile(filepath.Clean(path))
+		return readErr == nil && strings.Contains(string(data), `"nse-1"`)
+	}, time.Second, 10*time.Millisecond)
+}
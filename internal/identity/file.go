@@ -0,0 +1,154 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// FileSource - Source loading the X.509 SVID, its private key and the trust bundle from PEM files.
+// Files are reloaded when they change on disk, e.g. when a mounted secret is rotated.
+type FileSource struct {
+	certFile   string
+	keyFile    string
+	bundleFile string
+
+	mu     sync.RWMutex
+	svid   *x509svid.SVID
+	bundle *x509bundle.Bundle
+
+	cancel  context.CancelFunc
+	watcher *fsnotify.Watcher
+}
+
+// NewFileSource - creates FileSource and starts watching the files until ctx is done or the source is closed
+func NewFileSource(ctx context.Context, certFile, keyFile, bundleFile string) (*FileSource, error) {
+	s := &FileSource{
+		certFile:   certFile,
+		keyFile:    keyFile,
+		bundleFile: bundleFile,
+	}
+	if _, err := s.load(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create file watcher")
+	}
+	// Watch directories, files in the mounted secrets are replaced by symlink swap
+	for _, dir := range []string{filepath.Dir(certFile), filepath.Dir(keyFile), filepath.Dir(bundleFile)} {
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return nil, errors.Wrapf(err, "failed to watch %s", dir)
+		}
+	}
+	s.watcher = watcher
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	go s.watch(ctx)
+	return s, nil
+}
+
+// GetX509SVID - returns the current X.509 SVID
+func (s *FileSource) GetX509SVID() (*x509svid.SVID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.svid, nil
+}
+
+// GetX509BundleForTrustDomain - returns the trust bundle if it belongs to the trustDomain
+func (s *FileSource) GetX509BundleForTrustDomain(trustDomain spiffeid.TrustDomain) (*x509bundle.Bundle, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.bundle.GetX509BundleForTrustDomain(trustDomain)
+}
+
+// Close - stops watching the files
+func (s *FileSource) Close() error {
+	s.cancel()
+	return s.watcher.Close()
+}
+
+// load - loads the files, returns true if the identity is changed
+func (s *FileSource) load() (bool, error) {
+	svid, err := x509svid.Load(s.certFile, s.keyFile)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to load x509 svid")
+	}
+	// Tokens are signed with ES256, see spiffejwt.TokenGeneratorFunc
+	if _, ok := svid.PrivateKey.(*ecdsa.PrivateKey); !ok {
+		return false, errors.Errorf("x509 svid key %s must be an ECDSA key", s.keyFile)
+	}
+	bundle, err := x509bundle.Load(svid.ID.TrustDomain(), s.bundleFile)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to load x509 bundle")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := s.svid == nil || !s.svid.Certificates[0].Equal(svid.Certificates[0]) || !s.bundle.Equal(bundle)
+	s.svid, s.bundle = svid, bundle
+	return changed, nil
+}
+
+func (s *FileSource) watch(ctx context.Context) {
+	logger := log.FromContext(ctx).WithField("identity", "FileSource")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-s.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Rename|fsnotify.Remove) == 0 {
+				continue
+			}
+			// Files could be partially updated, so the previous identity is kept until all of them are valid
+			changed, err := s.load()
+			if err != nil {
+				logger.Debugf("identity is not reloaded on %v: %v", event, err)
+				continue
+			}
+			if !changed {
+				continue
+			}
+			svid, _ := s.GetX509SVID()
+			logger.Infof("identity reloaded, SVID: %q, expires at %v", svid.ID, svid.Certificates[0].NotAfter)
+		case err, ok := <-s.watcher.Errors:
+			if !ok {
+				return
+			}
+			logger.Errorf("file watcher error: %v", err)
+		}
+	}
+}
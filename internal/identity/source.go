@@ -0,0 +1,40 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package identity provides sources of the nsmgr X.509 SVID and trust bundle
+package identity
+
+import (
+	"io"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+const (
+	// WorkloadAPI - identity is obtained from the SPIFFE Workload API
+	WorkloadAPI = "workloadapi"
+	// File - identity is loaded from files on disk
+	File = "file"
+)
+
+// Source - source of the X.509 SVID and trust bundle used for mTLS and token signing.
+// *workloadapi.X509Source implements it.
+type Source interface {
+	x509svid.Source
+	x509bundle.Source
+	io.Closer
+}
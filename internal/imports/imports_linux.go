@@ -2,14 +2,30 @@
 package imports
 
 import (
+	_ "bufio"
+	_ "bytes"
 	_ "context"
+	_ "crypto/ecdsa"
+	_ "crypto/elliptic"
+	_ "crypto/rand"
+	_ "crypto/sha256"
+	_ "crypto/subtle"
 	_ "crypto/tls"
+	_ "crypto/x509"
+	_ "crypto/x509/pkix"
+	_ "encoding"
+	_ "encoding/hex"
+	_ "encoding/json"
+	_ "encoding/pem"
 	_ "fmt"
 	_ "github.com/antonfisher/nested-logrus-formatter"
 	_ "github.com/edwarnicke/genericsync"
 	_ "github.com/edwarnicke/grpcfd"
 	_ "github.com/edwarnicke/serialize"
+	_ "github.com/fsnotify/fsnotify"
 	_ "github.com/kelseyhightower/envconfig"
+	_ "github.com/mdlayher/vsock"
+	_ "github.com/networkservicemesh/api/pkg/api"
 	_ "github.com/networkservicemesh/api/pkg/api/networkservice"
 	_ "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
 	_ "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
@@ -22,28 +38,41 @@ import (
 	_ "github.com/networkservicemesh/sdk/pkg/networkservice/common/discover"
 	_ "github.com/networkservicemesh/sdk/pkg/networkservice/common/roundrobin"
 	_ "github.com/networkservicemesh/sdk/pkg/networkservice/common/setextracontext"
+	_ "github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	_ "github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/chains/client"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/common/memory"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/common/recvfd"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/common/updatepath"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/core/chain"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/utils/checks/checkcontext"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/utils/inject/injecterror"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/clock"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/debug"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/listenonurl"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/log"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/log/spanlogger"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/monitorconnection/authorize"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/opa"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/pprofutils"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/prometheus"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/sandbox"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/spire"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/token"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/tracing"
+	_ "github.com/open-policy-agent/opa/rego"
+	_ "github.com/pkg/errors"
 	_ "github.com/sirupsen/logrus"
+	_ "github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
 	_ "github.com/spiffe/go-spiffe/v2/spiffeid"
 	_ "github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
 	_ "github.com/spiffe/go-spiffe/v2/svid/x509svid"
@@ -51,19 +80,49 @@ import (
 	_ "github.com/stretchr/testify/assert"
 	_ "github.com/stretchr/testify/require"
 	_ "github.com/stretchr/testify/suite"
+	_ "go.opentelemetry.io/otel"
+	_ "go.opentelemetry.io/otel/attribute"
+	_ "go.opentelemetry.io/otel/exporters/prometheus"
+	_ "go.opentelemetry.io/otel/metric"
+	_ "go.opentelemetry.io/otel/sdk/metric"
+	_ "go.opentelemetry.io/otel/sdk/metric/metricdata"
+	_ "go.opentelemetry.io/otel/sdk/resource"
+	_ "go.opentelemetry.io/otel/sdk/trace"
+	_ "go.opentelemetry.io/otel/semconv/v1.4.0"
 	_ "google.golang.org/grpc"
+	_ "google.golang.org/grpc/codes"
 	_ "google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/health"
 	_ "google.golang.org/grpc/health/grpc_health_v1"
 	_ "google.golang.org/grpc/peer"
+	_ "google.golang.org/grpc/status"
+	_ "google.golang.org/protobuf/encoding/protojson"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "io"
+	_ "math/big"
 	_ "net"
+	_ "net/http"
+	_ "net/http/httptest"
+	_ "net/http/pprof"
 	_ "net/url"
 	_ "os"
 	_ "os/signal"
+	_ "os/user"
 	_ "path"
 	_ "path/filepath"
+	_ "reflect"
+	_ "regexp"
 	_ "runtime"
+	_ "sigs.k8s.io/yaml"
+	_ "sort"
+	_ "strconv"
+	_ "strings"
 	_ "sync"
+	_ "sync/atomic"
 	_ "syscall"
 	_ "testing"
+	_ "text/tabwriter"
 	_ "time"
 )
@@ -0,0 +1,178 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package listenprofile provides per-listener transport security profiles set by the query of the listen url, e.g.
+// unix:///var/lib/networkservicemesh/nsm.io.sock?peer_uid=0 or tcp://:5001?trust_domain=cluster.local
+package listenprofile
+
+import (
+	"crypto/tls"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+)
+
+const (
+	// TrustDomainParam - trust domain allowed for the mTLS peers of the listener, overrides the inbound allowlist
+	TrustDomainParam = "trust_domain"
+	// SpiffeIDPathParam - SPIFFE ID path pattern allowed for the mTLS peers of the listener, overrides the inbound
+	// allowlist
+	SpiffeIDPathParam = "spiffe_id_path"
+	// PeerUIDParam - UID of the processes allowed to connect to the unix listener
+	PeerUIDParam = "peer_uid"
+	// PeerGIDParam - GID of the processes allowed to connect to the unix listener
+	PeerGIDParam = "peer_gid"
+	// MinTLSVersionParam - minimum TLS version of the listener, 1.2 or 1.3
+	MinTLSVersionParam = "min_tls_version"
+	// TLSParam - tls=false disables TLS of the unix listener restricting the peer credentials
+	TLSParam = "tls"
+
+	unixScheme = "unix"
+)
+
+// Profile - transport security of a listener. Parameters can be repeated, e.g. trust_domain=a&trust_domain=b.
+type Profile struct {
+	TrustDomains  []string
+	SpiffeIDPaths []string
+	// PeerUIDs, PeerGIDs - peer credentials (SO_PEERCRED) allowed to connect, a peer matching any UID or GID is
+	// allowed. Empty lists allow any peer.
+	PeerUIDs      []uint32
+	PeerGIDs      []uint32
+	MinTLSVersion uint16
+	// Insecure - connections are not encrypted and the peers have no SPIFFE ID, the peers are identified only by
+	// their credentials
+	Insecure bool
+}
+
+// Parse - parses the profile from the query of u and removes the query, so u can be listened on and advertised.
+// Returns nil if u has no query.
+func Parse(u *url.URL) (*Profile, error) {
+	if u.RawQuery == "" {
+		return nil, nil
+	}
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid parameters of listen url %s", u.String())
+	}
+	p := &Profile{}
+	for key, values := range query {
+		switch key {
+		case TrustDomainParam:
+			p.TrustDomains = append(p.TrustDomains, values...)
+		case SpiffeIDPathParam:
+			p.SpiffeIDPaths = append(p.SpiffeIDPaths, values...)
+		case PeerUIDParam, PeerGIDParam:
+			if u.Scheme != unixScheme {
+				return nil, errors.Errorf("%s of listen url %s is supported only for unix sockets", key, u.String())
+			}
+			ids, err := parseIDs(values)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid %s of listen url %s", key, u.String())
+			}
+			if key == PeerUIDParam {
+				p.PeerUIDs = append(p.PeerUIDs, ids...)
+			} else {
+				p.PeerGIDs = append(p.PeerGIDs, ids...)
+			}
+		case TLSParam:
+			enabled, err := strconv.ParseBool(query.Get(key))
+			if err != nil {
+				return nil, errors.Errorf("invalid %s of listen url %s, expected true or false", key, u.String())
+			}
+			p.Insecure = !enabled
+		case MinTLSVersionParam:
+			if p.MinTLSVersion, err = parseTLSVersion(query.Get(key)); err != nil {
+				return nil, errors.Wrapf(err, "invalid %s of listen url %s", key, u.String())
+			}
+		default:
+			return nil, errors.Errorf("unknown parameter %q of listen url %s", key, u.String())
+		}
+	}
+	if p.Insecure && (!p.restrictsPeers() || len(p.TrustDomains) > 0 || len(p.SpiffeIDPaths) > 0 || p.MinTLSVersion != 0) {
+		return nil, errors.Errorf("listen url %s without TLS must have %s or %s and no TLS parameters", u.String(), PeerUIDParam, PeerGIDParam)
+	}
+	u.RawQuery = ""
+	return p, nil
+}
+
+// CheckPeer - returns error if the peer credentials are restricted and cred matches none of them
+func (p *Profile) CheckPeer(cred *peercred.Cred) error {
+	if !p.restrictsPeers() {
+		return nil
+	}
+	for _, uid := range p.PeerUIDs {
+		if uid == cred.UID {
+			return nil
+		}
+	}
+	for _, gid := range p.PeerGIDs {
+		if gid == cred.GID {
+			return nil
+		}
+	}
+	return errors.Errorf("peer %s is not allowed", cred.String())
+}
+
+func (p *Profile) String() string {
+	query := url.Values{}
+	query[TrustDomainParam] = p.TrustDomains
+	query[SpiffeIDPathParam] = p.SpiffeIDPaths
+	for _, uid := range p.PeerUIDs {
+		query.Add(PeerUIDParam, strconv.FormatUint(uint64(uid), 10))
+	}
+	for _, gid := range p.PeerGIDs {
+		query.Add(PeerGIDParam, strconv.FormatUint(uint64(gid), 10))
+	}
+	if p.Insecure {
+		query.Set(TLSParam, "false")
+	}
+	switch p.MinTLSVersion {
+	case tls.VersionTLS12:
+		query.Set(MinTLSVersionParam, "1.2")
+	case tls.VersionTLS13:
+		query.Set(MinTLSVersionParam, "1.3")
+	}
+	return query.Encode()
+}
+
+func (p *Profile) restrictsPeers() bool {
+	return len(p.PeerUIDs) > 0 || len(p.PeerGIDs) > 0
+}
+
+func parseIDs(values []string) ([]uint32, error) {
+	var ids []uint32
+	for _, value := range values {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, errors.Errorf("%q is not a numeric ID", value)
+		}
+		ids = append(ids, uint32(id))
+	}
+	return ids, nil
+}
+
+func parseTLSVersion(value string) (uint16, error) {
+	switch value {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, errors.Errorf("unsupported TLS version %q, expected 1.2 or 1.3", value)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listenprofile_test
+
+import (
+	"crypto/tls"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/listenprofile"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+)
+
+func TestParse(t *testing.T) {
+	u, err := url.Parse("tcp://:5001?trust_domain=a.org&trust_domain=b.org&spiffe_id_path=/ns/.*&min_tls_version=1.3")
+	require.NoError(t, err)
+
+	p, err := listenprofile.Parse(u)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.org", "b.org"}, p.TrustDomains)
+	require.Equal(t, []string{"/ns/.*"}, p.SpiffeIDPaths)
+	require.Equal(t, uint16(tls.VersionTLS13), p.MinTLSVersion)
+	require.Equal(t, "tcp://:5001", u.String())
+}
+
+func TestParse_NoQuery(t *testing.T) {
+	u := &url.URL{Scheme: "tcp", Host: ":5001"}
+	p, err := listenprofile.Parse(u)
+	require.NoError(t, err)
+	require.Nil(t, p)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, listenOn := range []string{
+		"tcp://:5001?peer_uid=0",
+		"unix:///nsm.sock?peer_uid=root",
+		"unix:///nsm.sock?min_tls_version=1.1",
+		"unix:///nsm.sock?unknown=1",
+		"unix:///nsm.sock?tls=false",
+		"unix:///nsm.sock?tls=false&peer_uid=0&trust_domain=a.org",
+	} {
+		u, err := url.Parse(listenOn)
+		require.NoError(t, err)
+		_, err = listenprofile.Parse(u)
+		require.Error(t, err, listenOn)
+	}
+}
+
+func TestParse_Insecure(t *testing.T) {
+	u, err := url.Parse("unix:///nsm.sock?tls=false&peer_gid=1000")
+	require.NoError(t, err)
+	p, err := listenprofile.Parse(u)
+	require.NoError(t, err)
+	require.True(t, p.Insecure)
+	require.Equal(t, "peer_gid=1000&tls=false", p.String())
+}
+
+func TestProfile_CheckPeer(t *testing.T) {
+	u, err := url.Parse("unix:///nsm.sock?peer_uid=0&peer_uid=1000&peer_gid=2000")
+	require.NoError(t, err)
+	p, err := listenprofile.Parse(u)
+	require.NoError(t, err)
+
+	require.NoError(t, p.CheckPeer(&peercred.Cred{UID: 1000, GID: 1000}))
+	require.NoError(t, p.CheckPeer(&peercred.Cred{UID: 3000, GID: 2000}))
+	require.Error(t, p.CheckPeer(&peercred.Cred{UID: 3000, GID: 3000}))
+	require.NoError(t, (&listenprofile.Profile{}).CheckPeer(&peercred.Cred{UID: 3000, GID: 3000}))
+}
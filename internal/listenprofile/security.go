@@ -0,0 +1,128 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listenprofile
+
+import (
+	"net"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+)
+
+// Security - transport credentials and peer credentials check of a listener with a profile
+type Security struct {
+	logger  log.Logger
+	profile *Profile
+	creds   credentials.TransportCredentials
+}
+
+// NewSecurity - creates Security handshaking the connections with creds after checking the peer credentials
+// allowed by profile
+func NewSecurity(logger log.Logger, profile *Profile, creds credentials.TransportCredentials) *Security {
+	return &Security{
+		logger:  logger,
+		profile: profile,
+		creds:   creds,
+	}
+}
+
+// Profile - returns the profile of the listener
+func (s *Security) Profile() *Profile {
+	return s.profile
+}
+
+// Listener - wraps ln, so its connections are handshaked with s by the ServerCredentials
+func (s *Security) Listener(ln net.Listener) net.Listener {
+	return &listener{Listener: ln, security: s}
+}
+
+func (s *Security) handshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if s.profile.restrictsPeers() {
+		cred, err := peercred.FromConn(rawConn)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = s.profile.CheckPeer(cred); err != nil {
+			s.logger.Warnf("Rejected connection to %s: %v", rawConn.LocalAddr().String(), err)
+			return nil, nil, err
+		}
+	}
+	return s.creds.ServerHandshake(rawConn)
+}
+
+// ServerCredentials - returns server transport credentials handshaking the connections accepted by the Security
+// listeners with their Security, and other connections with creds
+func ServerCredentials(creds credentials.TransportCredentials) credentials.TransportCredentials {
+	return &serverCredentials{TransportCredentials: creds}
+}
+
+type serverCredentials struct {
+	credentials.TransportCredentials
+}
+
+func (c *serverCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if conn, ok := rawConn.(securedConn); ok {
+		return conn.security().handshake(rawConn)
+	}
+	return c.TransportCredentials.ServerHandshake(rawConn)
+}
+
+func (c *serverCredentials) Clone() credentials.TransportCredentials {
+	return &serverCredentials{TransportCredentials: c.TransportCredentials.Clone()}
+}
+
+type securedConn interface {
+	security() *Security
+}
+
+type listener struct {
+	net.Listener
+	security *Security
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	// grpcfd passes file descriptors only over connections with unix socket methods, so they are kept
+	if unix, ok := conn.(*net.UnixConn); ok {
+		return &unixConn{UnixConn: unix, s: l.security}, nil
+	}
+	return &tcpConn{Conn: conn, s: l.security}, nil
+}
+
+type unixConn struct {
+	*net.UnixConn
+	s *Security
+}
+
+func (c *unixConn) security() *Security {
+	return c.s
+}
+
+type tcpConn struct {
+	net.Conn
+	s *Security
+}
+
+func (c *tcpConn) security() *Security {
+	return c.s
+}
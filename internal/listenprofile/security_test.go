@@ -0,0 +1,92 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package listenprofile_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/listenprofile"
+)
+
+type testCredentials struct {
+	credentials.TransportCredentials
+	handshakes int
+}
+
+func (c *testCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	c.handshakes++
+	return rawConn, nil, nil
+}
+
+func (c *testCredentials) ClientHandshake(_ context.Context, _ string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return rawConn, nil, nil
+}
+
+func acceptUnix(t *testing.T, wrap func(net.Listener) net.Listener) net.Conn {
+	ln, err := net.Listen("unix", filepath.Join(t.TempDir(), "test.sock"))
+	require.NoError(t, err)
+	ln = wrap(ln)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	client, err := net.Dial("unix", ln.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestServerCredentials(t *testing.T) {
+	defaultCreds, profileCreds := &testCredentials{}, &testCredentials{}
+	creds := listenprofile.ServerCredentials(defaultCreds)
+	security := listenprofile.NewSecurity(log.L(), &listenprofile.Profile{PeerUIDs: []uint32{uint32(os.Getuid())}}, profileCreds)
+
+	_, _, err := creds.ServerHandshake(acceptUnix(t, security.Listener))
+	require.NoError(t, err)
+	require.Equal(t, 1, profileCreds.handshakes)
+	require.Equal(t, 0, defaultCreds.handshakes)
+
+	conn := acceptUnix(t, func(ln net.Listener) net.Listener { return ln })
+	_, _, err = creds.ServerHandshake(conn)
+	require.NoError(t, err)
+	require.Equal(t, 1, profileCreds.handshakes)
+	require.Equal(t, 1, defaultCreds.handshakes)
+}
+
+func TestServerCredentials_PeerRejected(t *testing.T) {
+	profileCreds := &testCredentials{}
+	creds := listenprofile.ServerCredentials(&testCredentials{})
+	security := listenprofile.NewSecurity(log.L(), &listenprofile.Profile{PeerUIDs: []uint32{uint32(os.Getuid()) + 1}}, profileCreds)
+
+	_, _, err := creds.ServerHandshake(acceptUnix(t, security.Listener))
+	require.Error(t, err)
+	require.Equal(t, 0, profileCreds.handshakes)
+}
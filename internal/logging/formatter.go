@@ -0,0 +1,99 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	spanIDField  = "span_id"
+	traceIDField = "trace_id"
+)
+
+// spanSuffix - span ID appended to the message by the sdk trace logger
+var spanSuffix = regexp.MustCompile(` span=([0-9a-f]{16})$`)
+
+// jsonFormatter - logrus JSON formatter moving span ID added by the sdk trace logger from the message to a separate
+// field and adding trace ID of the span
+type jsonFormatter struct {
+	json  logrus.JSONFormatter
+	spans *spanTraces
+}
+
+func newJSONFormatter(spans *spanTraces) *jsonFormatter {
+	return &jsonFormatter{
+		json:  logrus.JSONFormatter{TimestampFormat: time.RFC3339Nano},
+		spans: spans,
+	}
+}
+
+func (f *jsonFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	m := spanSuffix.FindStringSubmatch(entry.Message)
+	if m == nil {
+		return f.json.Format(entry)
+	}
+	// Only IDs of the recording spans are moved, so the messages merely ending like the suffix are kept as is
+	traceID, ok := f.spans.traceID(m[1])
+	if !ok {
+		return f.json.Format(entry)
+	}
+	e := *entry
+	e.Data = make(logrus.Fields, len(entry.Data)+2)
+	for k, v := range entry.Data {
+		e.Data[k] = v
+	}
+	e.Message = e.Message[:len(e.Message)-len(m[0])]
+	e.Data[spanIDField] = m[1]
+	e.Data[traceIDField] = traceID
+	return f.json.Format(&e)
+}
+
+// spanTraces - span processor keeping trace IDs of the recording spans by their span IDs
+type spanTraces struct {
+	traces sync.Map
+}
+
+func (s *spanTraces) traceID(spanID string) (string, bool) {
+	traceID, ok := s.traces.Load(spanID)
+	if !ok {
+		return "", false
+	}
+	return traceID.(string), true
+}
+
+func (s *spanTraces) OnStart(_ context.Context, span sdktrace.ReadWriteSpan) {
+	s.traces.Store(span.SpanContext().SpanID().String(), span.SpanContext().TraceID().String())
+}
+
+func (s *spanTraces) OnEnd(span sdktrace.ReadOnlySpan) {
+	s.traces.Delete(span.SpanContext().SpanID().String())
+}
+
+func (s *spanTraces) Shutdown(context.Context) error {
+	return nil
+}
+
+func (s *spanTraces) ForceFlush(context.Context) error {
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/admin"
+)
+
+// Level - controls the level of logrus standard logger. The configured level is applied on startup and reload, it
+// can be overridden at runtime until Reset, optionally for a period of time after which the configured level is
+// restored.
+type Level struct {
+	logger log.Logger
+
+	mu         sync.Mutex
+	configured logrus.Level
+	set        bool
+	overridden bool
+	revertAt   time.Time
+	timer      *time.Timer
+}
+
+// LevelStatus - current and configured log levels
+type LevelStatus struct {
+	Level           string     `json:"level"`
+	ConfiguredLevel string     `json:"configuredLevel"`
+	Overridden      bool       `json:"overridden"`
+	RevertAt        *time.Time `json:"revertAt,omitempty"`
+}
+
+// NewLevel - creates Level
+func NewLevel(logger log.Logger) *Level {
+	return &Level{logger: logger}
+}
+
+// SetConfigured - applies the configured level if it is changed. Runtime override is kept until it expires.
+func (l *Level) SetConfigured(level logrus.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.set && l.configured == level {
+		return
+	}
+	l.configured, l.set = level, true
+	if l.overridden {
+		return
+	}
+	l.apply(level)
+}
+
+// Override - sets level, if duration is positive the configured level is restored after it
+func (l *Level) Override(level logrus.Level, duration time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stopTimer()
+	l.overridden = true
+	l.apply(level)
+	if duration <= 0 {
+		return
+	}
+	l.revertAt = time.Now().Add(duration)
+	var timer *time.Timer
+	timer = time.AfterFunc(duration, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.timer != timer {
+			return
+		}
+		l.timer, l.overridden = nil, false
+		l.logger.Infof("Log level override expired")
+		l.apply(l.configured)
+	})
+	l.timer = timer
+}
+
+// Reset - drops the runtime override and restores the configured level
+func (l *Level) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stopTimer()
+	l.overridden = false
+	l.apply(l.configured)
+}
+
+// ChangeOnSignal - sets trace level on traceSignal until resetSignal restores the configured level
+func (l *Level) ChangeOnSignal(ctx context.Context, traceSignal, resetSignal os.Signal) {
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, traceSignal, resetSignal)
+
+	go func() {
+		defer signal.Stop(signalCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-signalCh:
+				if sig == traceSignal {
+					l.logger.Infof("Received %v, setting trace log level", sig)
+					l.Override(logrus.TraceLevel, 0)
+					continue
+				}
+				l.logger.Infof("Received %v, restoring configured log level", sig)
+				l.Reset()
+			}
+		}
+	}()
+}
+
+// Status - returns current and configured log levels
+func (l *Level) Status() *LevelStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	status := &LevelStatus{
+		Level:           logrus.GetLevel().String(),
+		ConfiguredLevel: l.configured.String(),
+		Overridden:      l.overridden,
+	}
+	if l.timer != nil {
+		revertAt := l.revertAt
+		status.RevertAt = &revertAt
+	}
+	return status
+}
+
+// ServeHTTP - returns the log levels on GET. PUT and POST set the level from "level" parameter, "duration"
+// parameter sets the time after which the configured level is restored. DELETE restores the configured level.
+func (l *Level) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		level, err := logrus.ParseLevel(r.FormValue("level"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var duration time.Duration
+		if value := r.FormValue("duration"); value != "" {
+			if duration, err = time.ParseDuration(value); err != nil || duration < 0 {
+				http.Error(w, "invalid duration "+value, http.StatusBadRequest)
+				return
+			}
+		}
+		if duration > 0 {
+			l.logger.Infof("Log level is set to %s for %v by admin request from %s", level, duration, r.RemoteAddr)
+		} else {
+			l.logger.Infof("Log level is set to %s by admin request from %s", level, r.RemoteAddr)
+		}
+		l.Override(level, duration)
+	case http.MethodDelete:
+		l.logger.Infof("Log level override is dropped by admin request from %s", r.RemoteAddr)
+		l.Reset()
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	admin.WriteJSON(w, l.Status())
+}
+
+func (l *Level) stopTimer() {
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+}
+
+func (l *Level) apply(level logrus.Level) {
+	if level != logrus.GetLevel() {
+		l.logger.Infof("Setting log level to %s", level)
+	}
+	logrus.SetLevel(level)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/admin"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/logging"
+)
+
+func setLevel(t *testing.T, server http.Handler, token, query string) (int, *logging.LevelStatus) {
+	r := httptest.NewRequest(http.MethodPut, "/loglevel?"+query, http.NoBody)
+	r.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		return w.Code, nil
+	}
+	status := new(logging.LevelStatus)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), status))
+	return w.Code, status
+}
+
+func TestLevel_Override(t *testing.T) {
+	initial := logrus.GetLevel()
+	t.Cleanup(func() { logrus.SetLevel(initial) })
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0o600))
+
+	level := logging.NewLevel(log.L())
+	level.SetConfigured(logrus.InfoLevel)
+	server := admin.NewServer(admin.WithTokenFile(tokenFile))
+	server.HandleAuthenticated("/loglevel", level)
+
+	code, _ := setLevel(t, server, "wrong", "level=trace")
+	require.Equal(t, http.StatusUnauthorized, code)
+	code, _ = setLevel(t, server, "secret", "level=loud")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, status := setLevel(t, server, "secret", "level=trace&duration=100ms")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "trace", status.Level)
+	require.Equal(t, "info", status.ConfiguredLevel)
+	require.NotNil(t, status.RevertAt)
+
+	// Reload doesn't cancel the override
+	level.SetConfigured(logrus.WarnLevel)
+	require.Equal(t, logrus.TraceLevel, logrus.GetLevel())
+
+	require.Eventually(t, func() bool { return logrus.GetLevel() == logrus.WarnLevel }, time.Second, 10*time.Millisecond)
+	require.Nil(t, level.Status().RevertAt)
+}
+
+func TestLevel_OverrideWithoutDuration(t *testing.T) {
+	initial := logrus.GetLevel()
+	t.Cleanup(func() { logrus.SetLevel(initial) })
+
+	level := logging.NewLevel(log.L())
+	level.SetConfigured(logrus.InfoLevel)
+
+	level.Override(logrus.DebugLevel, 0)
+	require.True(t, level.Status().Overridden)
+	require.Nil(t, level.Status().RevertAt)
+
+	// Reload doesn't cancel the override
+	level.SetConfigured(logrus.WarnLevel)
+	require.Equal(t, logrus.DebugLevel, logrus.GetLevel())
+
+	level.Reset()
+	require.Equal(t, logrus.WarnLevel, logrus.GetLevel())
+	require.False(t, level.Status().Overridden)
+}
+
+func TestLevel_ChangeOnSignal(t *testing.T) {
+	initial := logrus.GetLevel()
+	t.Cleanup(func() { logrus.SetLevel(initial) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	level := logging.NewLevel(log.L())
+	level.SetConfigured(logrus.InfoLevel)
+	level.ChangeOnSignal(ctx, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+	require.Eventually(t, func() bool { return logrus.GetLevel() == logrus.TraceLevel }, time.Second, 10*time.Millisecond)
+
+	// SIGUSR2 restores the level configured after startup
+	level.SetConfigured(logrus.WarnLevel)
+	require.Equal(t, logrus.TraceLevel, logrus.GetLevel())
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR2))
+	require.Eventually(t, func() bool { return logrus.GetLevel() == logrus.WarnLevel }, time.Second, 10*time.Millisecond)
+}
+
+func TestLevel_TokenFileNotSet(t *testing.T) {
+	server := admin.NewServer()
+	server.HandleAuthenticated("/loglevel", logging.NewLevel(log.L()))
+
+	code, _ := setLevel(t, server, "", "level=trace")
+	require.Equal(t, http.StatusForbidden, code)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging configures the output format of the nsmgr logs
+package logging
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// Text - human readable output of the sdk logruslogger
+	Text = "text"
+	// JSON - one JSON object per line with the fields as separate keys
+	JSON = "json"
+
+	// SpiffeIDField - name of the field with SPIFFE ID of a peer
+	SpiffeIDField = "spiffe_id"
+)
+
+var spans = &spanTraces{}
+
+// Setup - sets logger as the global logger and switches the output of logrus standard logger to format. sdk trace
+// loggers are derived from the global logger, so they keep the format.
+func Setup(logger log.Logger, format string) error {
+	switch format {
+	case Text:
+	case JSON:
+		logrus.SetFormatter(newJSONFormatter(spans))
+	default:
+		return errors.Errorf("invalid log format %q, expected %s or %s", format, Text, JSON)
+	}
+	log.SetGlobalLogger(logger)
+	return nil
+}
+
+// RegisterSpanProcessor - makes trace IDs of the spans available for JSON output, should be called after the global
+// tracer provider is set
+func RegisterSpanProcessor() {
+	if tp, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); ok {
+		tp.RegisterSpanProcessor(spans)
+	}
+}
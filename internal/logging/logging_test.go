@@ -0,0 +1,109 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
+	"github.com/networkservicemesh/sdk/pkg/tools/log/spanlogger"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/logging"
+)
+
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var rv []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		entry := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		rv = append(rv, entry)
+	}
+	return rv
+}
+
+func TestSetup_JSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	output, formatter, globalLogger := logrus.StandardLogger().Out, logrus.StandardLogger().Formatter, log.L()
+	logrus.SetOutput(buf)
+	t.Cleanup(func() {
+		logrus.SetOutput(output)
+		logrus.SetFormatter(formatter)
+		log.SetGlobalLogger(globalLogger)
+	})
+
+	t.Setenv("TELEMETRY", "true")
+	tp := sdktrace.NewTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	ctx := context.Background()
+	logger := logruslogger.New(ctx, map[string]interface{}{"cmd": "nsmgr"})
+	require.Error(t, logging.Setup(logger, "xml"))
+	require.NoError(t, logging.Setup(logger, logging.JSON))
+	logging.RegisterSpanProcessor()
+
+	logger.WithField(logging.SpiffeIDField, "spiffe://example.org/nse").Info("multi\nline")
+	logger.WithField("id", "conn-2").Info("(1) no span=0123456789abcdef")
+
+	fields := []*log.Field{log.NewField("id", "conn-1"), log.NewField("type", "networkService")}
+	ctx, _, span, finish := spanlogger.FromContext(ctx, "", "Request", fields)
+	_, traceLogger, done := logruslogger.FromSpan(ctx, span, "", fields)
+	traceLogger.Infof("requested")
+
+	// The child span of the same connection overlaps the parent one, each entry keeps the ID of its own span
+	childCtx, _, childSpan, childFinish := spanlogger.FromContext(ctx, "", "Close", fields)
+	_, childLogger, childDone := logruslogger.FromSpan(childCtx, childSpan, "", fields)
+	childLogger.Infof("closing")
+	traceLogger.Infof("refreshed")
+	childDone()
+	childFinish()
+	done()
+	finish()
+
+	entries := lines(t, buf)
+	require.Len(t, entries, 5)
+	require.Equal(t, "nsmgr", entries[0]["cmd"])
+	require.Equal(t, "multi\nline", entries[0]["msg"])
+	require.Equal(t, "spiffe://example.org/nse", entries[0][logging.SpiffeIDField])
+
+	require.Equal(t, "(1) no span=0123456789abcdef", entries[1]["msg"])
+	require.NotContains(t, entries[1], "span_id")
+
+	require.Equal(t, "nsmgr", entries[2]["cmd"])
+	require.Equal(t, "requested", entries[2]["msg"])
+	require.Equal(t, "conn-1", entries[2]["id"])
+	require.Equal(t, span.ToString(), entries[2]["span_id"])
+	require.NotEmpty(t, entries[2]["trace_id"])
+
+	require.Equal(t, "closing", entries[3]["msg"])
+	require.Equal(t, childSpan.ToString(), entries[3]["span_id"])
+	require.Equal(t, entries[2]["trace_id"], entries[3]["trace_id"])
+
+	require.Equal(t, "refreshed", entries[4]["msg"])
+	require.Equal(t, span.ToString(), entries[4]["span_id"])
+	require.NotEqual(t, entries[3]["span_id"], entries[4]["span_id"])
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"net/http"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/admin"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/advertise"
+)
+
+// advertisedURL - advertised URL and the reason why it is selected, served on /status/advertise
+type advertisedURL struct {
+	URL       string `json:"url"`
+	Alternate string `json:"alternate,omitempty"`
+	Published string `json:"published"`
+	Reason    string `json:"reason"`
+}
+
+// initAdvertise - selects the URL nsmgr is published with
+func (m *manager) initAdvertise() error {
+	cidrs, err := advertise.ParseCIDRs(m.configuration.AdvertiseCIDRs)
+	if err != nil {
+		return err
+	}
+	opts := []advertise.Option{
+		advertise.WithURL(&m.configuration.AdvertiseURL),
+		advertise.WithInterfaceNames(m.configuration.AdvertiseInterfaces),
+		advertise.WithCIDRs(cidrs),
+		advertise.WithIPFamily(m.configuration.AdvertiseIPFamily),
+	}
+	if m.configuration.AdvertiseDualStack {
+		opts = append(opts, advertise.WithDualStack())
+	}
+	result, err := advertise.Select(m.listenOn, opts...)
+	if err != nil {
+		return err
+	}
+	m.logger.Infof("Advertising %s", result)
+	m.advertised = result
+	return nil
+}
+
+func (m *manager) advertiseHandler(w http.ResponseWriter, _ *http.Request) {
+	status := &advertisedURL{
+		URL:       m.advertised.URL.String(),
+		Published: m.advertised.Published().String(),
+		Reason:    m.advertised.Reason,
+	}
+	if m.advertised.Alternate != nil {
+		status.Alternate = m.advertised.Alternate.String()
+	}
+	admin.WriteJSON(w, status)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/connections"
+)
+
+// connectionsFile - name of the connections checkpoint file in the directory of the unix socket
+const connectionsFile = "nsmgr-connections.json"
+
+// initConnections - creates the store of the connections, they are checkpointed if CheckpointConnections is set
+func (m *manager) initConnections() {
+	if !m.configuration.CheckpointConnections {
+		m.connections = connections.NewStore()
+		return
+	}
+	path := stateFile(m.configuration, connectionsFile)
+	if path == "" {
+		m.logger.Warnf("Connections are not checkpointed, there is no unix listen url")
+		m.connections = connections.NewStore()
+		return
+	}
+	m.logger.Infof("Connections are checkpointed to %s", path)
+	m.connections = connections.NewStore(connections.WithCheckpoint(m.ctx, path, m.logger))
+}
+
+// restore - restores the local registrations and then the connections to them saved before restart
+func (m *manager) restore(spiffeIDConnMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]) {
+	m.restoreEndpoints()
+	m.restoreConnections(spiffeIDConnMap)
+}
+
+// restoreConnections - re-adopts the connections brokered before restart. Each connection is requested again through
+// the nsmgr chain with the forwarder and the endpoint from its path, so the following refresh and close from the client
+// find it established. Each request is limited by restoreTimeout.
+func (m *manager) restoreConnections(spiffeIDConnMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]) {
+	err := m.connections.Restore(m.ctx, func(ctx context.Context, conn *networkservice.Connection, spiffeID string) error {
+		ctx, cancel := context.WithTimeout(ctx, restoreTimeout)
+		defer cancel()
+		request := &networkservice.NetworkServiceRequest{
+			Connection:           conn,
+			MechanismPreferences: []*networkservice.Mechanism{conn.GetMechanism()},
+		}
+		if _, err := m.mgr.Request(ctx, request); err != nil {
+			return err
+		}
+		// The authorize server fills the map from the peer, there is no peer for the re-adopting request
+		id, err := spiffeid.FromString(spiffeID)
+		index := int(conn.GetPath().GetIndex())
+		if err != nil || index == 0 {
+			return nil
+		}
+		ids, _ := spiffeIDConnMap.LoadOrStore(id, new(genericsync.Map[string, struct{}]))
+		ids.Store(conn.GetPath().GetPathSegments()[index-1].GetId(), struct{}{})
+		return nil
+	})
+	if err != nil {
+		m.logger.Warnf("Connections are not restored: %v", err)
+	}
+}
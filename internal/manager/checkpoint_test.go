@@ -0,0 +1,118 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/google/uuid"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/client"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/count"
+	registryauthorize "github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+	registrychain "github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/connections"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/endpoints"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/policies"
+)
+
+func TestCheckpoint_Restart(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	var m *manager
+	spiffeIDConnMap := new(genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]])
+
+	// nsmgr of the node keeps the local registrations and the connections like nsmgr started by RunNsmgr and
+	// restores them in the background after (re)start
+	supplyNSMgr := func(ctx context.Context, tokenGenerator token.GeneratorFunc, options ...nsmgr.Option) nsmgr.Nsmgr {
+		m = newTestManager(ctx, time.Now().Add(time.Hour))
+		m.configuration = &config.Config{DialTimeout: time.Second}
+		registryPolicies, err := policies.NewRegistry(nil, nil)
+		require.NoError(t, err)
+		m.registryPolicies = registryPolicies
+		m.endpoints = endpoints.NewStore(ctx, filepath.Join(dir, endpointsFile), m.logger)
+		m.connections = connections.NewStore(connections.WithCheckpoint(ctx, filepath.Join(dir, connectionsFile), m.logger))
+		m.mgr = nsmgr.NewServer(ctx, tokenGenerator, append(options,
+			nsmgr.WithAuthorizeServer(chain.NewNetworkServiceServer(
+				m.connections.NetworkServiceServer(),
+				authorize.NewServer(authorize.Any()))),
+			nsmgr.WithAuthorizeNSERegistryServer(registrychain.NewNetworkServiceEndpointRegistryServer(
+				m.endpoints.NSEServer(),
+				registryauthorize.NewNetworkServiceEndpointRegistryServer(registryauthorize.Any()))),
+		)...)
+		go m.restore(spiffeIDConnMap)
+		return m.mgr
+	}
+
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetRegistryProxySupplier(nil).
+		SetNSMgrProxySupplier(nil).
+		SetNSMgrSupplier(supplyNSMgr).
+		Build()
+
+	_, err := domain.NewNSRegistryClient(ctx, sandbox.GenerateTestToken).Register(ctx, &registryapi.NetworkService{Name: "my-ns"})
+	require.NoError(t, err)
+	counter := new(count.Server)
+	domain.Nodes[0].NewEndpoint(ctx, &registryapi.NetworkServiceEndpoint{
+		Name:                "my-nse",
+		NetworkServiceNames: []string{"my-ns"},
+	}, sandbox.GenerateTestToken, counter)
+
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken, client.WithHealClient(null.NewClient()))
+	request := &networkservice.NetworkServiceRequest{
+		MechanismPreferences: []*networkservice.Mechanism{{Cls: cls.LOCAL, Type: kernel.MECHANISM}},
+		Connection:           &networkservice.Connection{Id: uuid.NewString(), NetworkService: "my-ns"},
+	}
+	conn, err := nsc.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.Requests())
+
+	// Restarted nsmgr registers the NSE and the forwarder again and requests the connection without the client
+	m.connections.Flush()
+	domain.Nodes[0].NSMgr.Restart()
+	require.Eventually(t, func() bool { return counter.Requests() == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return len(m.connections.List()) == 1 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, conn.GetNetworkServiceEndpointName(), m.connections.List()[0].GetNetworkServiceEndpointName())
+
+	// Client refresh finds the connection established with the same forwarder and endpoint
+	request.Connection = conn.Clone()
+	refreshed, err := nsc.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	require.Equal(t, conn.GetPath().GetPathSegments()[2].GetName(), refreshed.GetPath().GetPathSegments()[2].GetName())
+	require.Equal(t, 3, counter.Requests())
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/vsock"
+)
+
+// maxDialTimeout - dial timeout of sdk. sdk keeps its dial timeout for the lifetime of the chain, so it only bounds
+// DialTimeout, which is enforced by the context dialer and can be changed on reload.
+const maxDialTimeout = time.Minute
+
+// dialTimeoutError - dial error not retried by grpc, so the blocking dial of sdk fails when DialTimeout expires
+// instead of waiting for maxDialTimeout
+type dialTimeoutError struct {
+	error
+}
+
+func (e *dialTimeoutError) Temporary() bool {
+	return false
+}
+
+func (e *dialTimeoutError) Unwrap() error {
+	return e.error
+}
+
+// checkDialTimeout - returns error if dialTimeout can't be enforced within the dial timeout of sdk
+func checkDialTimeout(dialTimeout time.Duration) error {
+	if dialTimeout <= 0 || dialTimeout > maxDialTimeout {
+		return errors.Errorf("dial timeout %v should be positive and not greater than %v", dialTimeout, maxDialTimeout)
+	}
+	return nil
+}
+
+// dialContext - returns grpc context dialer connecting within the current dialTimeout
+func dialContext(dialTimeout *atomic.Int64, dial func(ctx context.Context, target string) (net.Conn, error)) func(ctx context.Context, target string) (net.Conn, error) {
+	return func(ctx context.Context, target string) (net.Conn, error) {
+		dialCtx, cancel := context.WithTimeout(ctx, time.Duration(dialTimeout.Load()))
+		defer cancel()
+		conn, err := dial(dialCtx, target)
+		if err != nil && ctx.Err() == nil && dialCtx.Err() != nil {
+			return nil, &dialTimeoutError{error: errors.Wrapf(err, "failed to dial %s within %v", target, time.Duration(dialTimeout.Load()))}
+		}
+		return conn, err
+	}
+}
+
+// contextDialer - returns context dialer of nsmgr, NSEs and forwarders running in VMs are dialed over vsock
+func (m *manager) contextDialer() func(ctx context.Context, target string) (net.Conn, error) {
+	return dialContext(&m.dialTimeout, vsock.DialContext)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestDialContext_Timeout(t *testing.T) {
+	// The target never answers, so the dial lasts until the context dialer gives up
+	hang := func(ctx context.Context, _ string) (net.Conn, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	var dialTimeout atomic.Int64
+	dial := func() time.Duration {
+		ctx, cancel := context.WithTimeout(context.Background(), maxDialTimeout)
+		defer cancel()
+		start := time.Now()
+		// nolint:staticcheck
+		_, err := grpc.DialContext(ctx, "passthrough:///nse", grpc.WithBlock(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithContextDialer(dialContext(&dialTimeout, hang)),
+			grpc.FailOnNonTempDialError(true))
+		require.Error(t, err)
+		return time.Since(start)
+	}
+
+	dialTimeout.Store(int64(100 * time.Millisecond))
+	require.Less(t, dial(), time.Second)
+
+	// Reloaded DialTimeout is used by the next dials
+	dialTimeout.Store(int64(1500 * time.Millisecond))
+	elapsed := dial()
+	require.GreaterOrEqual(t, elapsed, 1500*time.Millisecond)
+	require.Less(t, elapsed, 3*time.Second)
+}
+
+func TestCheckDialTimeout(t *testing.T) {
+	require.NoError(t, checkDialTimeout(750*time.Millisecond))
+	require.Error(t, checkDialTimeout(0))
+	require.Error(t, checkDialTimeout(maxDialTimeout+time.Second))
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const requestMethod = "/networkservice.NetworkService/Request"
+
+// drainer - rejects new NetworkService.Request calls and ends long-living streams (MonitorConnections, registry Find
+// with watch) once draining is started, so grpc.Server.GracefulStop has to wait only for the in-flight calls.
+type drainer struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newDrainer() *drainer {
+	d := new(drainer)
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	return d
+}
+
+func (d *drainer) start() {
+	d.cancel()
+}
+
+func (d *drainer) draining() bool {
+	return d.ctx.Err() != nil
+}
+
+func (d *drainer) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if info.FullMethod == requestMethod && d.draining() {
+			return nil, status.Error(codes.Unavailable, "nsmgr is shutting down")
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (d *drainer) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := context.WithCancel(ss.Context())
+		defer cancel()
+		stop := context.AfterFunc(d.ctx, cancel)
+		defer stop()
+		return handler(srv, &drainStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type drainStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *drainStream) Context() context.Context {
+	return s.ctx
+}
+
+// drain - marks nsmgr as NOT_SERVING except liveness and waits up to ShutdownGracePeriod for the in-flight calls to finish
+func (m *manager) drain() {
+	gracePeriod := m.configuration.ShutdownGracePeriod
+	if gracePeriod <= 0 || m.ctx.Err() != nil {
+		return
+	}
+	m.logger.Infof("Draining, waiting up to %v for in-flight calls", gracePeriod)
+
+	m.drainer.start()
+	m.setNotServing()
+
+	stopped := make(chan struct{})
+	go func() {
+		m.server.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+		m.logger.Infof("All in-flight calls are finished")
+	case <-timer.C:
+		m.logger.Warnf("Grace period %v expired, forcing stop", gracePeriod)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestDrainer_RejectsRequests(t *testing.T) {
+	d := newDrainer()
+	interceptor := d.unaryInterceptor()
+	handler := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: requestMethod}, handler)
+	require.NoError(t, err)
+
+	d.start()
+
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: requestMethod}, handler)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/networkservice.NetworkService/Close"}, handler)
+	require.NoError(t, err)
+}
+
+func TestDrainer_EndsStreams(t *testing.T) {
+	d := newDrainer()
+	interceptor := d.streamInterceptor()
+
+	started := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- interceptor(nil, &testServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{},
+			func(_ interface{}, stream grpc.ServerStream) error {
+				close(started)
+				<-stream.Context().Done()
+				return stream.Context().Err()
+			})
+	}()
+
+	<-started
+	d.start()
+
+	select {
+	case err := <-errCh:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("stream is not ended on drain")
+	}
+}
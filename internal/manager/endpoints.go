@@ -0,0 +1,77 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/endpoints"
+)
+
+const (
+	// endpointsFile - name of the file with the local registrations in the directory of the unix socket
+	endpointsFile = "nsmgr-endpoints.json"
+	// restoreTimeout - timeout of the registration of each restored NSE and of the request of each restored connection
+	restoreTimeout = 15 * time.Second
+)
+
+// initEndpoints - creates the store of the local registrations if PersistEndpoints is set
+func (m *manager) initEndpoints() {
+	if !m.configuration.PersistEndpoints {
+		return
+	}
+	path := stateFile(m.configuration, endpointsFile)
+	if path == "" {
+		m.logger.Warnf("Local registrations are not saved, there is no unix listen url")
+		return
+	}
+	m.logger.Infof("Local registrations are saved to %s", path)
+	m.endpoints = endpoints.NewStore(m.ctx, path, m.logger)
+}
+
+// stateFile - returns path of the file kept between restarts in the directory of the first unix listen url
+func stateFile(configuration *config.Config, name string) string {
+	for i := range configuration.ListenOn {
+		if u := &configuration.ListenOn[i]; u.Scheme == "unix" {
+			return filepath.Join(filepath.Dir(u.Path), name)
+		}
+	}
+	return ""
+}
+
+// restoreEndpoints - registers the local NSEs and forwarders saved before restart again, if they are still listening
+func (m *manager) restoreEndpoints() {
+	if m.endpoints == nil {
+		return
+	}
+	err := m.endpoints.Restore(m.ctx, time.Duration(m.dialTimeout.Load()), func(ctx context.Context, r *endpoints.Record) error {
+		ctx, cancel := context.WithTimeout(ctx, restoreTimeout)
+		defer cancel()
+		if _, err := m.mgr.NetworkServiceEndpointRegistryServer().Register(ctx, r.NSE()); err != nil {
+			return err
+		}
+		// Path IDs are the ones of nsmgr after the registration above, NSE must be able to refresh it
+		m.registryPolicies.RestoreNSE(r.Name, r.PathIDs)
+		return nil
+	})
+	if err != nil {
+		m.logger.Warnf("Local registrations are not restored: %v", err)
+	}
+}
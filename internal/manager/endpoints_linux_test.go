@@ -0,0 +1,120 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package manager
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/edwarnicke/grpcfd"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/sendfd"
+	registryadapter "github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/policies"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/ratelimit"
+)
+
+// newEndpointsTestManager - creates manager with the registry chain of nsmgr listening on nsmSocket
+func newEndpointsTestManager(ctx context.Context, t *testing.T, nsmSocket string) *manager {
+	cfg, err := config.Load("nsm")
+	require.NoError(t, err)
+	cfg.ListenOn = []url.URL{{Scheme: "unix", Path: nsmSocket}}
+	cfg.PersistEndpoints = true
+	cfg.DialTimeout = time.Second
+	// The test NSE has no certificate, so the server policies checking the previous token signature are not used
+	cfg.RegistryServerPolicies = []string{"etc/nsm/opa/common/.*.rego", "etc/nsm/opa/registry/.*.rego"}
+
+	m := newTestManager(ctx, time.Now().Add(time.Hour))
+	m.configuration = cfg
+	m.registryPolicies, err = policies.NewRegistry(cfg.RegistryServerPolicies, cfg.RegistryClientPolicies)
+	require.NoError(t, err)
+	m.rateLimits = ratelimit.NewServer(m.logger, new(genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]))
+	m.initEndpoints()
+	m.mgr = nsmgr.NewServer(ctx, sandbox.GenerateTestToken,
+		nsmgr.WithURL("unix://"+nsmSocket),
+		nsmgr.WithAuthorizeNSERegistryServer(m.nseRegistryServer()))
+	return m
+}
+
+func TestEndpoints_Restore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	nsmSocket := filepath.Join(dir, "nsm.io.sock")
+	nseSocket := filepath.Join(dir, "nse.sock")
+
+	nseListener, err := net.Listen("unix", nseSocket)
+	require.NoError(t, err)
+	defer func() { _ = nseListener.Close() }()
+
+	// NSE registers over the unix socket passing its socket by file descriptor, as sdk endpoints do
+	m := newEndpointsTestManager(ctx, t, nsmSocket)
+	server := grpc.NewServer(grpc.Creds(grpcfd.TransportCredentials(insecure.NewCredentials())))
+	m.mgr.Register(server)
+	nsmListener, err := net.Listen("unix", nsmSocket)
+	require.NoError(t, err)
+	go func() { _ = server.Serve(nsmListener) }()
+
+	cc, err := grpc.NewClient("unix://"+nsmSocket, grpc.WithTransportCredentials(grpcfd.TransportCredentials(insecure.NewCredentials())))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+	_, err = chain.NewNetworkServiceEndpointRegistryClient(
+		sendfd.NewNetworkServiceEndpointRegistryClient(),
+		registry.NewNetworkServiceEndpointRegistryClient(cc),
+	).Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"my-service"},
+		Url:                 "unix://" + nseSocket,
+	})
+	require.NoError(t, err)
+	// Stopping nsmgr writes the pending changes
+	server.Stop()
+	m.endpoints.Flush()
+
+	// nsmgr restarts and registers the NSE again by the path of its socket
+	restartCtx, restartCancel := context.WithCancel(ctx)
+	defer restartCancel()
+	m = newEndpointsTestManager(restartCtx, t, nsmSocket)
+	m.restoreEndpoints()
+
+	stream, err := registryadapter.NetworkServiceEndpointServerToClient(m.mgr.NetworkServiceEndpointRegistryServer()).Find(ctx,
+		&registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "nse-1"}})
+	require.NoError(t, err)
+	nses := registry.ReadNetworkServiceEndpointList(stream)
+	require.Len(t, nses, 1)
+	require.Equal(t, "unix://"+nseSocket, nses[0].GetUrl())
+	require.Equal(t, []string{"my-service"}, nses[0].GetNetworkServiceNames())
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/admin"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/listenprofile"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peerauth"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/unixsocket"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/vsock"
+)
+
+const unixScheme = "unix"
+
+// listenerState - state of a supervised listener, served on /status/listeners
+type listenerState struct {
+	URL      string `json:"url"`
+	Critical bool   `json:"critical"`
+	// Profile - transport security profile of the listener, empty if the listener uses the inbound mTLS settings
+	Profile string `json:"profile,omitempty"`
+	Serving bool   `json:"serving"`
+	// Restarts - number of restarts since nsmgr is started
+	Restarts int `json:"restarts"`
+	// Failures - number of failures in a row, reset when the listener is serving for the max restart backoff
+	Failures  int       `json:"failures"`
+	LastError string    `json:"lastError,omitempty"`
+	Changed   time.Time `json:"changed"`
+
+	security *listenprofile.Security
+}
+
+type listenersState struct {
+	mu     sync.Mutex
+	states []*listenerState
+}
+
+// initListenProfiles - parses transport security profiles from the queries of the listen urls and validates vsock
+// urls. Listen urls without the queries are used for listening and advertising, the configuration is kept as is to
+// be compared on reload.
+func (m *manager) initListenProfiles() error {
+	m.listenOn = append([]url.URL(nil), m.configuration.ListenOn...)
+	m.listenProfiles = make([]*listenprofile.Profile, len(m.listenOn))
+	for i := range m.listenOn {
+		profile, err := listenprofile.Parse(&m.listenOn[i])
+		if err != nil {
+			return err
+		}
+		if m.listenOn[i].Scheme == vsock.Scheme {
+			if _, _, err = vsock.ParseURL(&m.listenOn[i]); err != nil {
+				return err
+			}
+		}
+		if profile != nil {
+			m.logger.Infof("Listener %s uses transport security profile %s", m.listenOn[i].String(), profile.String())
+		}
+		m.listenProfiles[i] = profile
+	}
+	return nil
+}
+
+// listenSecurity - creates mTLS transport credentials of the listener with profile. Trust domains and SPIFFE ID
+// paths of the profile replace the inbound allowlists, TLS version is never lower than 1.2. Insecure listeners rely
+// on the peer credentials only.
+func (m *manager) listenSecurity(profile *listenprofile.Profile) (*listenprofile.Security, error) {
+	if profile.Insecure {
+		return listenprofile.NewSecurity(m.logger, profile, GrpcfdTransportCredentials(insecure.NewCredentials())), nil
+	}
+	authorizer := m.inboundAuthorizer
+	if len(profile.TrustDomains) > 0 || len(profile.SpiffeIDPaths) > 0 {
+		var err error
+		if authorizer, err = m.newPeerAuthorizer(peerauth.Inbound, profile.TrustDomains, profile.SpiffeIDPaths); err != nil {
+			return nil, err
+		}
+	}
+	tlsConfig := tlsconfig.MTLSServerConfig(m.source, m.source, authorizer.Authorize())
+	tlsConfig.MinVersion = tls.VersionTLS12
+	if profile.MinTLSVersion > tlsConfig.MinVersion {
+		tlsConfig.MinVersion = profile.MinTLSVersion
+	}
+	return listenprofile.NewSecurity(m.logger, profile, GrpcfdTransportCredentials(credentials.NewTLS(tlsConfig))), nil
+}
+
+// prepareListeners - creates directories of the unix listen urls and removes stale sockets left by a previous nsmgr
+func (m *manager) prepareListeners() error {
+	var err error
+	if m.unixSockets, err = unixsocket.ParseConfig(m.configuration.UnixSocketDirMode, m.configuration.UnixSocketMode,
+		m.configuration.UnixSocketOwner, m.configuration.UnixSocketGroup); err != nil {
+		return err
+	}
+	for i := range m.listenOn {
+		u := &m.listenOn[i]
+		if u.Scheme != unixScheme {
+			continue
+		}
+		removed, err := m.unixSockets.Prepare(u.Path)
+		if err != nil {
+			return err
+		}
+		if removed {
+			m.logger.Infof("Removed stale socket %s", u.Path)
+		}
+	}
+	return nil
+}
+
+// startServers - serves on every listen url, returns when each listener has started or failed to start. Failed
+// listeners are restarted with backoff, a critical listener failing more than ListenerMaxRestarts times in a row
+// stops nsmgr.
+func (m *manager) startServers(server *grpc.Server) error {
+	securities := make([]*listenprofile.Security, len(m.listenOn))
+	for i, profile := range m.listenProfiles {
+		if profile == nil {
+			continue
+		}
+		var err error
+		if securities[i], err = m.listenSecurity(profile); err != nil {
+			return err
+		}
+	}
+
+	go func() {
+		<-m.ctx.Done()
+		server.Stop()
+	}()
+
+	var wg sync.WaitGroup
+	m.listeners.mu.Lock()
+	for i := range m.listenOn {
+		listenURL := &m.listenOn[i]
+		state := &listenerState{URL: listenURL.String(), Critical: m.critical(listenURL), Changed: time.Now(), security: securities[i]}
+		if securities[i] != nil {
+			state.Profile = securities[i].Profile().String()
+		}
+		m.listeners.states = append(m.listeners.states, state)
+
+		wg.Add(1)
+		go m.supervise(listenURL, state, server, wg.Done)
+	}
+	m.listeners.mu.Unlock()
+	wg.Wait()
+	return nil
+}
+
+// critical - returns true if the scheme or the url of the listener is in CriticalListeners
+func (m *manager) critical(listenURL *url.URL) bool {
+	for _, critical := range m.configuration.CriticalListeners {
+		if critical == listenURL.Scheme || critical == listenURL.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *manager) supervise(listenURL *url.URL, state *listenerState, server *grpc.Server, started func()) {
+	delay := m.configuration.ListenerRestartBackoff
+	for {
+		serveStart := time.Now()
+		err := m.serve(listenURL, state, server, started)
+		started = func() {}
+		// Serve returns without error on graceful stop, the manager stops everything itself after draining
+		if err == nil || errors.Is(err, grpc.ErrServerStopped) || m.ctx.Err() != nil {
+			return
+		}
+		if time.Since(serveStart) >= m.configuration.ListenerRestartMaxBackoff {
+			delay = m.configuration.ListenerRestartBackoff
+			m.resetListenerFailures(state)
+		}
+		failures := m.listenerFailed(state, err)
+		if state.Critical && failures > m.configuration.ListenerMaxRestarts {
+			m.logger.Errorf("Critical listener %s failed %d times in a row: %v, stopping nsmgr", listenURL.String(), failures, err)
+			m.cancelFunc()
+			return
+		}
+
+		m.logger.Warnf("Listener %s failed: %v, restarting in %v", listenURL.String(), err, delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-m.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if delay *= 2; delay > m.configuration.ListenerRestartMaxBackoff {
+			delay = m.configuration.ListenerRestartMaxBackoff
+		}
+		m.listenerRestarted(state)
+	}
+}
+
+// serve - listens on listenURL and serves until the listener fails, started is called when listening is started or
+// failed to start
+func (m *manager) serve(listenURL *url.URL, state *listenerState, server *grpc.Server, started func()) error {
+	ln, err := m.listen(listenURL)
+	if err != nil {
+		started()
+		return err
+	}
+	if state.security != nil {
+		ln = state.security.Listener(ln)
+	}
+	m.logger.Infof("NSMGR Listening on: %v", listenURL.String())
+	m.setListenerServing(state, listenURL.String(), true)
+	started()
+	err = server.Serve(ln)
+	m.setListenerServing(state, listenURL.String(), false)
+	return err
+}
+
+// listen - creates listener for listenURL, unix sockets are created with the configured mode and ownership. Port 0
+// of tcp url is replaced with the actual one, so the restarted listener gets the same port.
+func (m *manager) listen(listenURL *url.URL) (net.Listener, error) {
+	switch listenURL.Scheme {
+	case unixScheme:
+		return m.unixSockets.Listen(listenURL.Path)
+	case vsock.Scheme:
+		return vsock.Listen(listenURL)
+	}
+	ln, err := net.Listen("tcp", listenURL.Host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to listen on %s", listenURL.String())
+	}
+	*listenURL = *grpcutils.AddressToURL(ln.Addr())
+	return ln, nil
+}
+
+func (m *manager) setListenerServing(state *listenerState, url string, serving bool) {
+	m.listeners.mu.Lock()
+	defer m.listeners.mu.Unlock()
+	state.URL, state.Serving, state.Changed = url, serving, time.Now()
+}
+
+func (m *manager) listenerRestarted(state *listenerState) {
+	m.listeners.mu.Lock()
+	state.Restarts++
+	u := state.URL
+	m.listeners.mu.Unlock()
+	m.metrics.ListenerRestarted(m.ctx, u)
+}
+
+func (m *manager) listenerFailed(state *listenerState, err error) int {
+	m.listeners.mu.Lock()
+	defer m.listeners.mu.Unlock()
+	state.Failures++
+	state.LastError = err.Error()
+	return state.Failures
+}
+
+func (m *manager) resetListenerFailures(state *listenerState) {
+	m.listeners.mu.Lock()
+	defer m.listeners.mu.Unlock()
+	state.Failures = 0
+}
+
+// listenersServing - returns whether the listeners are serving by their urls
+func (m *manager) listenersServing() map[string]bool {
+	m.listeners.mu.Lock()
+	defer m.listeners.mu.Unlock()
+	serving := make(map[string]bool, len(m.listeners.states))
+	for _, state := range m.listeners.states {
+		serving[state.URL] = state.Serving
+	}
+	return serving
+}
+
+// checkListeners - returns error if some listener is not serving
+func (m *manager) checkListeners() error {
+	for u, serving := range m.listenersServing() {
+		if !serving {
+			return errors.Errorf("listener %s is not serving", u)
+		}
+	}
+	return nil
+}
+
+func (m *manager) listenersHandler(w http.ResponseWriter, _ *http.Request) {
+	m.listeners.mu.Lock()
+	states := make([]listenerState, 0, len(m.listeners.states))
+	for _, state := range m.listeners.states {
+		states = append(states, *state)
+	}
+	m.listeners.mu.Unlock()
+
+	admin.WriteJSON(w, states)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/metrics"
+)
+
+func newListenTestManager(t *testing.T, listenOn string, critical ...string) *manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	m := newTestManager(ctx, time.Now().Add(time.Hour))
+	m.cancelFunc = cancel
+	m.listenOn = []url.URL{{Scheme: "tcp", Host: listenOn}}
+	m.configuration.CriticalListeners = critical
+	m.configuration.ListenerRestartBackoff = 10 * time.Millisecond
+	m.configuration.ListenerRestartMaxBackoff = 50 * time.Millisecond
+
+	var err error
+	m.metrics, err = metrics.New(m.source)
+	require.NoError(t, err)
+	return m
+}
+
+func TestListeners_Restart(t *testing.T) {
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	m := newListenTestManager(t, busy.Addr().String())
+	require.NoError(t, m.startServers(grpc.NewServer()))
+	require.Error(t, m.checkListeners())
+
+	require.NoError(t, busy.Close())
+	require.Eventually(t, func() bool { return m.checkListeners() == nil }, time.Second, 10*time.Millisecond)
+	require.NoError(t, m.ctx.Err())
+
+	m.listeners.mu.Lock()
+	defer m.listeners.mu.Unlock()
+	require.Positive(t, m.listeners.states[0].Restarts)
+	require.NotEmpty(t, m.listeners.states[0].LastError)
+}
+
+func TestListeners_CriticalStopsNsmgr(t *testing.T) {
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = busy.Close() }()
+
+	m := newListenTestManager(t, busy.Addr().String(), "tcp")
+	m.configuration.ListenerMaxRestarts = 2
+	require.NoError(t, m.startServers(grpc.NewServer()))
+
+	select {
+	case <-m.ctx.Done():
+	case <-time.After(time.Second):
+		require.FailNow(t, "nsmgr is not stopped by the failed critical listener")
+	}
+	m.listeners.mu.Lock()
+	defer m.listeners.mu.Unlock()
+	require.Equal(t, 3, m.listeners.states[0].Failures)
+}
+
+func TestListeners_Profiles(t *testing.T) {
+	m := newTestManager(context.Background(), time.Now().Add(time.Hour))
+	m.configuration.ListenOn = []url.URL{
+		{Scheme: "unix", Path: "/var/lib/networkservicemesh/nsm.io.sock", RawQuery: "peer_uid=0"},
+		{Scheme: "tcp", Host: ":5001"},
+	}
+	require.NoError(t, m.initListenProfiles())
+
+	require.Equal(t, "unix:///var/lib/networkservicemesh/nsm.io.sock", m.listenOn[0].String())
+	require.Equal(t, []uint32{0}, m.listenProfiles[0].PeerUIDs)
+	require.Nil(t, m.listenProfiles[1])
+	// The configuration is kept as is, so reload doesn't report the listen urls as changed
+	require.Equal(t, "peer_uid=0", m.configuration.ListenOn[0].RawQuery)
+}
@@ -4,6 +4,8 @@
 //
 // Copyright (c) 2022-2025 Nordix and/or its affiliates.
 //
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
 // SPDX-License-Identifier: Apache-2.0
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -24,15 +26,14 @@ package manager
 import (
 	"context"
 	"crypto/tls"
-	"net"
 	"net/url"
-	"os"
-	"path"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/edwarnicke/genericsync"
 	"github.com/edwarnicke/grpcfd"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
@@ -40,96 +41,330 @@ import (
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/api/pkg/api"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
-	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
-	registryauthorize "github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
-	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
-	"github.com/networkservicemesh/sdk/pkg/tools/listenonurl"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
-	authmonitor "github.com/networkservicemesh/sdk/pkg/tools/monitorconnection/authorize"
-	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 	"github.com/networkservicemesh/sdk/pkg/tools/tracing"
 
+	"github.com/networkservicemesh/cmd-nsmgr/internal/advertise"
 	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
-)
-
-const (
-	tcpSchema = "tcp"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/connections"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/endpoints"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/identity"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/listenprofile"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/metrics"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peerauth"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/policies"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/ratelimit"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/registryfailover"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/unixsocket"
 )
 
 type manager struct {
-	ctx           context.Context
-	logger        log.Logger
-	configuration *config.Config
-	cancelFunc    context.CancelFunc
-	mgr           nsmgr.Nsmgr
-	source        *workloadapi.X509Source
-	svid          *x509svid.SVID
-	server        *grpc.Server
+	ctx                context.Context
+	logger             log.Logger
+	configuration      *config.Config
+	cancelFunc         context.CancelFunc
+	mgr                nsmgr.Nsmgr
+	source             identity.Source
+	svid               *x509svid.SVID
+	server             *grpc.Server
+	health             *health.Server
+	healthServices     []string
+	readiness          readinessState
+	drainer            *drainer
+	registryPolicies   *policies.Registry
+	connectionPolicies *policies.Connection
+	metrics            *metrics.Metrics
+	connections        *connections.Store
+	endpoints          *endpoints.Store
+	listenOn           []url.URL
+	listenProfiles     []*listenprofile.Profile
+	unixSockets        *unixsocket.Config
+	listeners          listenersState
+	advertised         *advertise.Result
+	rateLimits         *ratelimit.Server
+	peerCreds          *peercred.Capturer
+	peerCredsServer    networkservice.NetworkServiceServer
+	peerIssuer         *peercred.Issuer
+	policies           policiesState
+	inboundAuthorizer  *peerauth.Authorizer
+	outboundAuthorizer *peerauth.Authorizer
+	peerAuthorizers    peerAuthorizersState
+	registrySelector   *registryfailover.Selector
+	dialTimeout        atomic.Int64
+	maxTokenLifetime   atomic.Int64
 }
 
 func (m *manager) Stop() {
+	m.drain()
 	m.cancelFunc()
 	m.server.Stop()
+	m.connections.Flush()
+	if m.endpoints != nil {
+		m.endpoints.Flush()
+	}
 	_ = m.source.Close()
 }
 
-func (m *manager) initSecurity() (err error) {
-	// Get a X509Source
-	logrus.Infof("Obtaining X509 Certificate Source")
-	m.source, err = workloadapi.NewX509Source(m.ctx)
+func (m *manager) initSecurity(ctx context.Context) (err error) {
+	switch m.configuration.IdentitySource {
+	case identity.File:
+		logrus.Infof("Loading X509 Certificate Source from files")
+		m.source, err = identity.NewFileSource(m.ctx, m.configuration.X509CertFile, m.configuration.X509KeyFile, m.configuration.X509BundleFile)
+		if err != nil {
+			return err
+		}
+		m.svid, err = m.source.GetX509SVID()
+		if err != nil {
+			_ = m.source.Close()
+			return errors.Wrap(err, "error getting x509 svid")
+		}
+	case identity.WorkloadAPI, "":
+		// Get a X509Source, SPIRE agent could be not ready yet
+		logrus.Infof("Obtaining X509 Certificate Source")
+		m.setReadiness([]readinessCheck{{Name: svidCheck, Error: "waiting for SVID from SPIFFE Workload API"}})
+		b := backoff{attemptTimeout: workloadAPIAttemptTimeout, initial: workloadAPIInitialBackoff, max: workloadAPIMaxBackoff}
+		err = retry(ctx, m.logger, "SPIFFE Workload API", m.configuration.WorkloadAPITimeout, b, m.initWorkloadAPISource)
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("unknown identity source %q", m.configuration.IdentitySource)
+	}
+	logrus.Infof("SVID: %q", m.svid.ID)
+	return nil
+}
+
+func (m *manager) initWorkloadAPISource(ctx context.Context) error {
+	source, err := workloadapi.NewX509Source(ctx)
 	if err != nil {
-		logrus.Fatalf("error getting x509 source: %+v", err)
+		return errors.Wrap(err, "error getting x509 source")
 	}
-	m.svid, err = m.source.GetX509SVID()
+	svid, err := source.GetX509SVID()
 	if err != nil {
-		logrus.Fatalf("error getting x509 svid: %+v", err)
+		_ = source.Close()
+		return errors.Wrap(err, "error getting x509 svid")
 	}
-	logrus.Infof("SVID: %q", m.svid.ID)
-	return
+	m.source, m.svid = source, svid
+	return nil
+}
+
+func (m *manager) initPeerAuthorizers() (err error) {
+	m.inboundAuthorizer, err = m.newPeerAuthorizer(peerauth.Inbound, m.configuration.InboundTrustDomains, m.configuration.InboundSpiffeIDPaths)
+	if err != nil {
+		return err
+	}
+	m.outboundAuthorizer, err = m.newPeerAuthorizer(peerauth.Outbound, m.configuration.OutboundTrustDomains, m.configuration.OutboundSpiffeIDPaths)
+	return err
+}
+
+// peerAuthorizersState - all the mTLS peer authorizers of nsmgr, including the ones of the listener profiles
+type peerAuthorizersState struct {
+	mu          sync.Mutex
+	authorizers []*peerauth.Authorizer
+}
+
+// newPeerAuthorizer - creates peer authorizer whose rejections are reported by nsmgr_peer_rejected metric
+func (m *manager) newPeerAuthorizer(direction string, trustDomains, pathPatterns []string) (*peerauth.Authorizer, error) {
+	authorizer, err := peerauth.NewAuthorizer(m.logger, direction, trustDomains, pathPatterns)
+	if err != nil {
+		return nil, err
+	}
+	m.peerAuthorizers.mu.Lock()
+	defer m.peerAuthorizers.mu.Unlock()
+	m.peerAuthorizers.authorizers = append(m.peerAuthorizers.authorizers, authorizer)
+	return authorizer, nil
+}
+
+// peerRejections - returns the number of rejected mTLS peers by direction
+func (m *manager) peerRejections() map[string]uint64 {
+	m.peerAuthorizers.mu.Lock()
+	defer m.peerAuthorizers.mu.Unlock()
+
+	rejected := map[string]uint64{peerauth.Inbound: 0, peerauth.Outbound: 0}
+	for _, authorizer := range m.peerAuthorizers.authorizers {
+		rejected[authorizer.Direction()] += authorizer.Rejected()
+	}
+	return rejected
 }
 
 // RunNsmgr - start nsmgr.
-func RunNsmgr(ctx context.Context, configuration *config.Config) error {
+func RunNsmgr(ctx context.Context, configuration *config.Config, opts ...Option) error {
 	starttime := time.Now()
 
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if err := checkDialTimeout(configuration.DialTimeout); err != nil {
+		return err
+	}
+
 	m := &manager{
 		configuration: configuration,
 		logger:        log.FromContext(ctx),
+		health:        health.NewServer(),
+		drainer:       newDrainer(),
 	}
+	m.dialTimeout.Store(int64(configuration.DialTimeout))
+	m.maxTokenLifetime.Store(int64(configuration.MaxTokenLifetime))
+
+	// Context to use for all things started in main. It is cancelled only after draining is finished.
+	m.ctx, m.cancelFunc = context.WithCancel(context.WithoutCancel(ctx))
 
-	// Context to use for all things started in main
-	m.ctx, m.cancelFunc = context.WithCancel(ctx)
+	// Probes are served before waiting for the SVID, so the wait is visible in the readiness reasons
+	if o.adminServer != nil {
+		o.adminServer.HandleFunc("/healthz", m.livenessHandler)
+		o.adminServer.HandleFunc("/readyz", m.readinessHandler)
+	}
 
-	if err := m.initSecurity(); err != nil {
+	if err := m.initSecurity(ctx); err != nil {
 		m.logger.Errorf("failed to create new spiffe TLS Peer %v", err)
+		m.cancelFunc()
+		return err
+	}
+
+	var err error
+	if m.metrics, err = metrics.New(m.source); err != nil {
+		m.cancelFunc()
+		_ = m.source.Close()
+		return err
+	}
+	if err = m.metrics.ObservePeerRejections(m.peerRejections); err != nil {
+		m.cancelFunc()
+		_ = m.source.Close()
+		return err
+	}
+	if err = m.metrics.ObserveListeners(m.listenersServing); err != nil {
+		m.cancelFunc()
+		_ = m.source.Close()
+		return err
+	}
+
+	spiffeIDConnMap := genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]{}
+	if err := m.initPolicies(&spiffeIDConnMap); err != nil {
+		m.cancelFunc()
+		_ = m.source.Close()
+		return err
+	}
+	if err := m.initRateLimits(&spiffeIDConnMap); err != nil {
+		m.cancelFunc()
+		_ = m.source.Close()
+		return err
+	}
+	if err := m.initPeerCreds(); err != nil {
+		m.cancelFunc()
+		_ = m.source.Close()
 		return err
 	}
 
-	u := genPublishableURL(configuration.ListenOn, m.logger)
+	if err := m.initListenProfiles(); err != nil {
+		m.logger.Errorf("failed to parse listen urls: %v", err)
+		m.cancelFunc()
+		_ = m.source.Close()
+		return err
+	}
+	if err := m.initAdvertise(); err != nil {
+		m.logger.Errorf("failed to select advertise url: %v", err)
+		m.cancelFunc()
+		_ = m.source.Close()
+		return err
+	}
+	u := m.advertised.Published()
 
-	tlsClientConfig := tlsconfig.MTLSClientConfig(m.source, m.source, tlsconfig.AuthorizeAny())
+	if err := m.initPeerAuthorizers(); err != nil {
+		m.cancelFunc()
+		_ = m.source.Close()
+		return err
+	}
+	tlsClientConfig := tlsconfig.MTLSClientConfig(m.source, m.source, m.outboundAuthorizer.Authorize())
 	tlsClientConfig.MinVersion = tls.VersionTLS12
-	tlsServerConfig := tlsconfig.MTLSServerConfig(m.source, m.source, tlsconfig.AuthorizeAny())
+	tlsServerConfig := tlsconfig.MTLSServerConfig(m.source, m.source, m.inboundAuthorizer.Authorize())
 	tlsServerConfig.MinVersion = tls.VersionTLS12
-	spiffeIDConnMap := genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]{}
+	if err := m.initRegistry(tlsClientConfig); err != nil {
+		m.cancelFunc()
+		_ = m.source.Close()
+		return err
+	}
+	// If we Listen on Unix socket for local connections we need to be sure folder are exist
+	if err := m.prepareListeners(); err != nil {
+		m.logger.Errorf("failed to prepare listen urls: %v", err)
+		m.cancelFunc()
+		_ = m.source.Close()
+		return err
+	}
+
+	m.initEndpoints()
+	m.initConnections()
+	mgrOptions := m.nsmgrOptions(u, tlsClientConfig)
+
+	m.mgr = nsmgr.NewServer(m.ctx, m.tokenGenerator(), mgrOptions...)
+	// Clients may refresh their connections while they are restored, so nsmgr starts serving right away
+	go m.restore(&spiffeIDConnMap)
+
+	m.server = m.newServer(tlsServerConfig)
+
+	// Create GRPC server
+	if err := m.startServers(m.server); err != nil {
+		m.logger.Errorf("failed to start listeners: %v", err)
+		m.Stop()
+		return err
+	}
+
+	if o.reloader != nil {
+		o.reloader.Subscribe(m.reload)
+	}
+	go m.runReadiness(u)
+
+	if o.adminServer != nil {
+		o.adminServer.HandleFunc("/status/policies", m.policiesHandler)
+		o.adminServer.HandleFunc("/status/advertise", m.advertiseHandler)
+		o.adminServer.HandleFunc("/status/listeners", m.listenersHandler)
+		o.adminServer.HandleAuthenticated("/connections", connections.Handler(m.connections, &spiffeIDConnMap))
+	}
+
+	m.logger.Infof("Startup completed in %v", time.Since(starttime))
+	starttime = time.Now()
+	select {
+	case <-ctx.Done():
+	case <-m.ctx.Done():
+	}
+
+	m.logger.Infof("Exit requested. Uptime: %v", time.Since(starttime))
+	// If we here we need to call Stop
+	m.Stop()
+	return nil
+}
+
+func (m *manager) nsmgrOptions(u *url.URL, tlsClientConfig *tls.Config) []nsmgr.Option {
 	mgrOptions := []nsmgr.Option{
-		nsmgr.WithName(configuration.Name),
+		nsmgr.WithName(m.configuration.Name),
 		nsmgr.WithURL(u.String()),
-		nsmgr.WithAuthorizeServer(authorize.NewServer(authorize.WithSpiffeIDConnectionMap(&spiffeIDConnMap))),
-		nsmgr.WithAuthorizeMonitorConnectionServer(authmonitor.NewMonitorConnectionServer(authmonitor.WithSpiffeIDConnectionMap(&spiffeIDConnMap))),
-		nsmgr.WithAuthorizeNSERegistryServer(registryauthorize.NewNetworkServiceEndpointRegistryServer(
-			registryauthorize.WithPolicies(configuration.RegistryServerPolicies...))),
-		nsmgr.WithAuthorizeNSERegistryClient(registryauthorize.NewNetworkServiceEndpointRegistryClient(
-			registryauthorize.WithPolicies(configuration.RegistryClientPolicies...))),
-		nsmgr.WithAuthorizeNSRegistryServer(registryauthorize.NewNetworkServiceRegistryServer(
-			registryauthorize.WithPolicies(configuration.RegistryServerPolicies...))),
-		nsmgr.WithAuthorizeNSRegistryClient(registryauthorize.NewNetworkServiceRegistryClient(
-			registryauthorize.WithPolicies(configuration.RegistryClientPolicies...))),
-		nsmgr.WithDialTimeout(configuration.DialTimeout),
-		nsmgr.WithForwarderServiceName(configuration.ForwarderNetworkServiceName),
+		nsmgr.WithAuthorizeServer(chain.NewNetworkServiceServer(
+			m.metrics.NetworkServiceServer(),
+			m.peerCredsServer,
+			m.rateLimits.NetworkServiceServer(),
+			m.connections.NetworkServiceServer(),
+			m.connectionPolicies.NetworkServiceServer(),
+		)),
+		nsmgr.WithAuthorizeMonitorConnectionServer(m.connectionPolicies.MonitorConnectionServer()),
+		nsmgr.WithAuthorizeNSERegistryServer(m.nseRegistryServer()),
+		nsmgr.WithAuthorizeNSERegistryClient(m.nseRegistryClient()),
+		nsmgr.WithAuthorizeNSRegistryServer(m.nsRegistryServer()),
+		nsmgr.WithAuthorizeNSRegistryClient(m.nsRegistryClient()),
+		nsmgr.WithDialTimeout(maxDialTimeout),
+		nsmgr.WithForwarderServiceName(m.configuration.ForwarderNetworkServiceName),
 		nsmgr.WithDialOptions(
 			append(tracing.WithTracingDial(),
 				grpc.WithTransportCredentials(
@@ -138,8 +373,11 @@ func RunNsmgr(ctx context.Context, configuration *config.Config) error {
 					),
 				),
 				grpc.WithBlock(),
+				// DialTimeout is enforced by the context dialer, its timeout fails the blocking dial right away
+				grpc.WithContextDialer(m.contextDialer()),
+				grpc.FailOnNonTempDialError(true),
 				grpc.WithDefaultCallOptions(
-					grpc.PerRPCCredentials(token.NewPerRPCCredentials(spiffejwt.TokenGeneratorFunc(m.source, configuration.MaxTokenLifetime))),
+					grpc.PerRPCCredentials(token.NewPerRPCCredentials(m.tokenGenerator())),
 				),
 				grpcfd.WithChainStreamInterceptor(),
 				grpcfd.WithChainUnaryInterceptor(),
@@ -147,95 +385,49 @@ func RunNsmgr(ctx context.Context, configuration *config.Config) error {
 		),
 	}
 
-	if configuration.RegistryURL.String() != "" {
-		mgrOptions = append(mgrOptions, nsmgr.WithRegistry(&configuration.RegistryURL))
+	if !m.standalone() {
+		mgrOptions = append(mgrOptions, nsmgr.WithRegistry(&m.configuration.RegistryURL))
 	}
+	return mgrOptions
+}
 
-	m.mgr = nsmgr.NewServer(m.ctx, spiffejwt.TokenGeneratorFunc(m.source, m.configuration.MaxTokenLifetime), mgrOptions...)
-
-	// If we Listen on Unix socket for local connections we need to be sure folder are exist
-	createListenFolders(configuration)
-
+// newServer - creates gRPC server of nsmgr serving mTLS connections and, on the listeners with tls=false, the
+// connections without TLS
+func (m *manager) newServer(tlsServerConfig *tls.Config) *grpc.Server {
 	serverOptions := append(
 		tracing.WithTracing(),
 		grpc.Creds(
-			GrpcfdTransportCredentials(
-				credentials.NewTLS(tlsServerConfig),
+			m.peerCreds.TransportCredentials(
+				listenprofile.ServerCredentials(
+					GrpcfdTransportCredentials(
+						credentials.NewTLS(tlsServerConfig),
+					),
+				),
 			),
 		),
+		grpc.ChainUnaryInterceptor(m.peerCreds.UnaryServerInterceptor(), m.drainer.unaryInterceptor()),
+		grpc.ChainStreamInterceptor(m.peerCreds.StreamServerInterceptor(), m.drainer.streamInterceptor()),
 	)
 
-	m.server = grpc.NewServer(serverOptions...)
-	m.mgr.Register(m.server)
-
-	// Create GRPC server
-	m.startServers(m.server)
-
-	m.logger.Infof("Startup completed in %v", time.Since(starttime))
-	starttime = time.Now()
-	<-m.ctx.Done()
-
-	m.logger.Infof("Exit requested. Uptime: %v", time.Since(starttime))
-	// If we here we need to call Stop
-	m.Stop()
-	return nil
+	server := grpc.NewServer(serverOptions...)
+	m.register(server)
+	return server
 }
 
-func createListenFolders(configuration *config.Config) {
-	for i := 0; i < len(configuration.ListenOn); i++ {
-		u := &configuration.ListenOn[i]
-		if u.Scheme == "unix" {
-			nsmDir, _ := path.Split(u.Path)
-			_ = os.MkdirAll(nsmDir, os.ModeDir|os.ModePerm)
-		}
-	}
-}
-
-func waitErrChan(ctx context.Context, errChan <-chan error, m *manager) {
-	select {
-	case <-ctx.Done():
-	case err := <-errChan:
-		// We need to cal cancel global context, since it could be multiple context of this kind
-		m.cancelFunc()
-		m.logger.Warnf("failed to serve: %v", err)
-	}
-}
-
-func (m *manager) startServers(server *grpc.Server) {
-	var wg sync.WaitGroup
-	for i := 0; i < len(m.configuration.ListenOn); i++ {
-		listenURL := &m.configuration.ListenOn[i]
-		wg.Add(1)
+// register - registers nsmgr services with own health server, so the health status could be changed on drain
+func (m *manager) register(server *grpc.Server) {
+	networkservice.RegisterNetworkServiceServer(server, m.mgr)
+	networkservice.RegisterMonitorConnectionServer(server, m.mgr)
+	registryapi.RegisterNetworkServiceRegistryServer(server, m.mgr.NetworkServiceRegistryServer())
+	registryapi.RegisterNetworkServiceEndpointRegistryServer(server, m.mgr.NetworkServiceEndpointRegistryServer())
 
-		go func() {
-			// Create a required number of servers
-			errChan := grpcutils.ListenAndServe(m.ctx, listenURL, server)
-			m.logger.Infof("NSMGR Listening on: %v", listenURL.String())
-			// For public schemas we need to perform registation of nsmgr into registry.
-			wg.Done()
-
-			waitErrChan(m.ctx, errChan, m)
-		}()
-	}
-	wg.Wait()
-}
-
-func genPublishableURL(listenOn []url.URL, logger log.Logger) *url.URL {
-	u := defaultURL(listenOn)
-	addrs, err := net.InterfaceAddrs()
-	if err != nil {
-		logger.Warn(err.Error())
-		return u
+	grpc_health_v1.RegisterHealthServer(server, m.health)
+	for _, impl := range []interface{}{m.mgr, m.mgr.NetworkServiceEndpointRegistryServer(), m.mgr.NetworkServiceRegistryServer()} {
+		m.healthServices = append(m.healthServices, api.ServiceNames(impl)...)
 	}
-	return listenonurl.GetPublicURL(addrs, u)
-}
-
-func defaultURL(listenOn []url.URL) *url.URL {
-	for i := 0; i < len(listenOn); i++ {
-		u := &listenOn[i]
-		if u.Scheme == tcpSchema {
-			return u
-		}
+	for _, service := range m.healthServices {
+		m.health.SetServingStatus(service, grpc_health_v1.HealthCheckResponse_SERVING)
 	}
-	return &listenOn[0]
+	m.health.SetServingStatus(livenessService, grpc_health_v1.HealthCheckResponse_SERVING)
+	m.health.SetServingStatus(readinessService, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
 }
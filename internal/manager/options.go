@@ -0,0 +1,44 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"github.com/networkservicemesh/cmd-nsmgr/internal/admin"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/reload"
+)
+
+type options struct {
+	reloader    *reload.Reloader
+	adminServer *admin.Server
+}
+
+// Option - option for RunNsmgr
+type Option func(o *options)
+
+// WithReloader - sets reloader providing runtime configuration changes
+func WithReloader(reloader *reload.Reloader) Option {
+	return func(o *options) {
+		o.reloader = reloader
+	}
+}
+
+// WithAdminServer - sets admin HTTP server to register nsmgr status endpoints on
+func WithAdminServer(adminServer *admin.Server) Option {
+	return func(o *options) {
+		o.adminServer = adminServer
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+)
+
+// initPeerCreds - creates capturer of the peer credentials of the unix socket connections, the chain element
+// authorizing the callers without SPIFFE ID by UID and the issuer of their tokens
+func (m *manager) initPeerCreds() error {
+	uidServices, err := peercred.ParseUIDServices(m.configuration.PeerUIDNetworkServices)
+	if err != nil {
+		return err
+	}
+	if len(uidServices) > 0 {
+		m.logger.Infof("Network services allowed by UID for unix socket callers without SPIFFE ID: %v", uidServices)
+	}
+	m.peerCreds = peercred.NewCapturer()
+	m.peerIssuer = peercred.NewIssuer(m.source, m.configuration.Name, func() time.Duration {
+		return time.Duration(m.maxTokenLifetime.Load())
+	})
+	m.peerCredsServer = peercred.NewServer(m.logger, uidServices, m.peerIssuer)
+	return nil
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package manager
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/endpoint"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/registry/chains/memory"
+	registryauthorize "github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/metrics"
+)
+
+// svidSource - source of SVID signed by the test CA
+type svidSource struct {
+	svid   *x509svid.SVID
+	bundle *x509bundle.Bundle
+}
+
+func (s *svidSource) GetX509SVID() (*x509svid.SVID, error) {
+	return s.svid, nil
+}
+
+func (s *svidSource) GetX509BundleForTrustDomain(trustDomain spiffeid.TrustDomain) (*x509bundle.Bundle, error) {
+	return s.bundle.GetX509BundleForTrustDomain(trustDomain)
+}
+
+func (s *svidSource) Close() error {
+	return nil
+}
+
+// newSVIDSources - creates the sources of SVIDs with ids signed by the same CA
+func newSVIDSources(t *testing.T, ids ...string) []*svidSource {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		URIs:                  []*url.URL{{Scheme: "spiffe", Host: "example.org"}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	bundle := x509bundle.FromX509Authorities(spiffeid.RequireTrustDomainFromString("example.org"), []*x509.Certificate{ca})
+
+	var sources []*svidSource
+	for i, id := range ids {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		spiffeID := spiffeid.RequireFromString(id)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(i + 2)),
+			NotBefore:    time.Now().Add(-time.Minute),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+			URIs:         []*url.URL{spiffeID.URL()},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		sources = append(sources, &svidSource{
+			svid:   &x509svid.SVID{ID: spiffeID, Certificates: []*x509.Certificate{cert}, PrivateKey: key},
+			bundle: bundle,
+		})
+	}
+	return sources
+}
+
+// serveTLS - serves the services registered by register over mTLS on the unix socket
+func serveTLS(ctx context.Context, t *testing.T, source *svidSource, socket string, register func(*grpc.Server)) {
+	tlsConfig := tlsconfig.MTLSServerConfig(source, source, tlsconfig.AuthorizeAny())
+	tlsConfig.MinVersion = tls.VersionTLS12
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	register(server)
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	go func() {
+		<-ctx.Done()
+		server.Stop()
+	}()
+}
+
+// newPeerCredTestManager - starts nsmgr listening on nsmSocket without TLS for the callers with the current UID,
+// the shipped policies accepting such callers replace the server policies of sdk
+func newPeerCredTestManager(ctx context.Context, t *testing.T, source *svidSource, nsmSocket, registrySocket string) *manager {
+	shipped, err := filepath.Abs("../../etc/nsmgr/opa/server")
+	require.NoError(t, err)
+	cfg, err := config.Load("nsm")
+	require.NoError(t, err)
+	cfg.Name = "nsmgr-1"
+	cfg.RegistryURL = url.URL{Scheme: "unix", Path: registrySocket}
+	cfg.ListenOn = []url.URL{{Scheme: "unix", Path: nsmSocket, RawQuery: "tls=false&peer_uid=" + strconv.Itoa(os.Getuid())}}
+	cfg.NetworkServicePolicies = []string{"etc/nsm/opa/common/.*.rego", shipped + "/.*.rego"}
+	cfg.RegistryServerPolicies = []string{"etc/nsm/opa/common/.*.rego", "etc/nsm/opa/registry/.*.rego", shipped + "/.*.rego"}
+	cfg.PeerUIDNetworkServices = []string{strconv.Itoa(os.Getuid()) + ":my-service"}
+	cfg.DialTimeout = time.Second
+
+	m := newTestManager(ctx, time.Now().Add(time.Hour))
+	m.configuration = cfg
+	m.source = source
+	m.dialTimeout.Store(int64(cfg.DialTimeout))
+	m.maxTokenLifetime.Store(int64(cfg.MaxTokenLifetime))
+	m.metrics, err = metrics.New(m.source)
+	require.NoError(t, err)
+
+	spiffeIDConnMap := genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]{}
+	require.NoError(t, m.initPolicies(&spiffeIDConnMap))
+	require.NoError(t, m.initRateLimits(&spiffeIDConnMap))
+	require.NoError(t, m.initPeerCreds())
+	require.NoError(t, m.initListenProfiles())
+	require.NoError(t, m.initPeerAuthorizers())
+	require.NoError(t, m.prepareListeners())
+	m.initEndpoints()
+	m.initConnections()
+
+	tlsClientConfig := tlsconfig.MTLSClientConfig(m.source, m.source, m.outboundAuthorizer.Authorize())
+	tlsClientConfig.MinVersion = tls.VersionTLS12
+	tlsServerConfig := tlsconfig.MTLSServerConfig(m.source, m.source, m.inboundAuthorizer.Authorize())
+	tlsServerConfig.MinVersion = tls.VersionTLS12
+
+	u := &url.URL{Scheme: "unix", Path: nsmSocket}
+	m.mgr = nsmgr.NewServer(m.ctx, m.tokenGenerator(), m.nsmgrOptions(u, tlsClientConfig)...)
+	m.server = m.newServer(tlsServerConfig)
+	require.NoError(t, m.startServers(m.server))
+	return m
+}
+
+func TestPeerCred_InsecureUnixListener(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	nsmSocket := filepath.Join(dir, "nsm.io.sock")
+	registrySocket := filepath.Join(dir, "registry.sock")
+	forwarderSocket := filepath.Join(dir, "forwarder.sock")
+	sources := newSVIDSources(t, "spiffe://example.org/nsmgr", "spiffe://example.org/registry", "spiffe://example.org/forwarder")
+
+	// Registry and forwarder check the tokens of the path with the default sdk policies
+	defaultPolicies := []string{"etc/nsm/opa/common/.*.rego", "etc/nsm/opa/registry/.*.rego", "etc/nsm/opa/server/.*.rego"}
+	registryServer := memory.NewServer(ctx, spiffejwt.TokenGeneratorFunc(sources[1], time.Minute),
+		memory.WithAuthorizeNSRegistryServer(registryauthorize.NewNetworkServiceRegistryServer(registryauthorize.WithPolicies(defaultPolicies...))),
+		memory.WithAuthorizeNSERegistryServer(registryauthorize.NewNetworkServiceEndpointRegistryServer(registryauthorize.WithPolicies(defaultPolicies...))))
+	serveTLS(ctx, t, sources[1], registrySocket, registryServer.Register)
+	forwarder := endpoint.NewServer(ctx, spiffejwt.TokenGeneratorFunc(sources[2], time.Minute), endpoint.WithName("forwarder"))
+	serveTLS(ctx, t, sources[2], forwarderSocket, forwarder.Register)
+
+	newPeerCredTestManager(ctx, t, sources[0], nsmSocket, registrySocket)
+
+	// Callers have neither certificates nor tokens, nsmgr issues tokens for them
+	cc, err := grpc.NewClient("unix://"+nsmSocket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	issuedID := "spiffe://example.org/nsmgr/nsmgr-1/uid/" + strconv.Itoa(os.Getuid())
+	_, err = chain.NewNetworkServiceRegistryClient(
+		grpcmetadata.NewNetworkServiceRegistryClient(),
+		registry.NewNetworkServiceRegistryClient(cc),
+	).Register(ctx, &registry.NetworkService{Name: "my-service"})
+	require.NoError(t, err)
+	nseClient := chain.NewNetworkServiceEndpointRegistryClient(
+		grpcmetadata.NewNetworkServiceEndpointRegistryClient(),
+		registry.NewNetworkServiceEndpointRegistryClient(cc),
+	)
+	_, err = nseClient.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "forwarder-1",
+		NetworkServiceNames: []string{"forwarder"},
+		Url:                 "unix://" + forwarderSocket,
+	})
+	require.NoError(t, err)
+	nse, err := nseClient.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"my-service"},
+		Url:                 "unix://" + filepath.Join(dir, "nse.sock"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, issuedID, nse.GetPathIds()[0])
+
+	// The token issued for the client passes the policies of nsmgr and the default policies of the forwarder
+	nsClient := networkservice.NewNetworkServiceClient(cc)
+	conn, err := nsClient.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "nsc-1",
+			NetworkService: "my-service",
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Name: "nsc", Id: "nsc-1"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	claims := new(jwt.RegisteredClaims)
+	_, err = jwt.ParseWithClaims(conn.GetPath().GetPathSegments()[0].GetToken(), claims, func(*jwt.Token) (interface{}, error) {
+		return sources[0].svid.Certificates[0].PublicKey, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, issuedID, claims.Subject)
+	require.Equal(t, "forwarder", conn.GetPath().GetPathSegments()[len(conn.GetPath().GetPathSegments())-1].GetName())
+	_, err = nsClient.Close(ctx, conn)
+	require.NoError(t, err)
+
+	// Network services not allowed for the UID are rejected before the policies
+	_, err = nsClient.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "nsc-2",
+			NetworkService: "other-service",
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Name: "nsc", Id: "nsc-2"}},
+			},
+		},
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/admin"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/policies"
+)
+
+// policiesStatus - currently loaded policies, reported by the status endpoint
+type policiesStatus struct {
+	LastReload        time.Time       `json:"lastReload"`
+	LastError         string          `json:"lastError,omitempty"`
+	RegistryServer    []policies.File `json:"registryServer"`
+	RegistryClient    []policies.File `json:"registryClient"`
+	NetworkService    []policies.File `json:"networkService"`
+	MonitorConnection []policies.File `json:"monitorConnection"`
+}
+
+// policiesState - policy paths of the current configuration and the status of the last policies reload
+type policiesState struct {
+	mu      sync.Mutex
+	cfg     *config.Config
+	version uint64
+	status  policiesStatus
+	watcher *policies.Watcher
+}
+
+func policyMasks(cfg *config.Config) [][]string {
+	return [][]string{cfg.RegistryServerPolicies, cfg.RegistryClientPolicies, cfg.NetworkServicePolicies, cfg.MonitorConnectionPolicies}
+}
+
+// initPolicies - creates authorize chain elements and starts watching the policy files
+func (m *manager) initPolicies(spiffeIDConnMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]) (err error) {
+	if m.registryPolicies, err = policies.NewRegistry(m.configuration.RegistryServerPolicies, m.configuration.RegistryClientPolicies); err != nil {
+		return err
+	}
+	if m.connectionPolicies, err = policies.NewConnection(spiffeIDConnMap, m.configuration.NetworkServicePolicies, m.configuration.MonitorConnectionPolicies); err != nil {
+		return err
+	}
+	m.policies.mu.Lock()
+	m.policies.cfg = m.configuration
+	m.recordPolicies(nil)
+	m.policies.mu.Unlock()
+
+	if m.policies.watcher, err = policies.NewWatcher(m.ctx, m.reloadPolicies); err != nil {
+		return err
+	}
+	var masks []string
+	for _, ms := range policyMasks(m.configuration) {
+		masks = append(masks, ms...)
+	}
+	return m.policies.watcher.Watch(masks...)
+}
+
+// updatePolicies - replaces all the policies with the ones from cfg. Policies are replaced only if all of them are
+// compiled successfully.
+func (m *manager) updatePolicies(cfg *config.Config) error {
+	apply, err := m.preparePolicies(cfg)
+	if err != nil {
+		return err
+	}
+	apply()
+	return nil
+}
+
+// preparePolicies - compiles all the policies from cfg, returned function replaces registry and connection policies
+// together under the policies lock
+func (m *manager) preparePolicies(cfg *config.Config) (apply func(), err error) {
+	m.policies.mu.Lock()
+	version := m.policies.version
+	m.policies.mu.Unlock()
+
+	applyRegistry, err := m.registryPolicies.Prepare(cfg.RegistryServerPolicies, cfg.RegistryClientPolicies)
+	if err != nil {
+		return nil, m.policiesFailed(err)
+	}
+	applyConnection, err := m.connectionPolicies.Prepare(cfg.NetworkServicePolicies, cfg.MonitorConnectionPolicies)
+	if err != nil {
+		return nil, m.policiesFailed(err)
+	}
+	var masks []string
+	for _, ms := range policyMasks(cfg) {
+		masks = append(masks, ms...)
+	}
+
+	return func() {
+		m.policies.mu.Lock()
+		defer m.policies.mu.Unlock()
+
+		applyRegistry()
+		applyConnection()
+		m.policies.cfg = cfg
+		m.policies.version++
+		if err := m.policies.watcher.Watch(masks...); err != nil {
+			m.logger.Warnf("Policies are reloaded, but not watched: %v", err)
+		}
+		m.recordPolicies(nil)
+
+		// Policies were replaced by a concurrent update after these ones were compiled, they could be older than
+		// the replaced ones, so the files are loaded once more
+		if m.policies.version != version+1 {
+			go m.reloadPolicies()
+		}
+	}, nil
+}
+
+// reloadPolicies - reloads the policies of the current configuration when the policy files are changed
+func (m *manager) reloadPolicies() {
+	m.policies.mu.Lock()
+	cfg := m.policies.cfg
+	m.policies.mu.Unlock()
+
+	_ = m.updatePolicies(cfg)
+}
+
+// policiesFailed - records failed policies reload, returns err
+func (m *manager) policiesFailed(err error) error {
+	m.policies.mu.Lock()
+	defer m.policies.mu.Unlock()
+
+	m.recordPolicies(err)
+	return err
+}
+
+// recordPolicies - logs result of the policies reload and updates the status, m.policies.mu must be held
+func (m *manager) recordPolicies(err error) {
+	m.policies.status.LastReload = time.Now()
+	if err != nil {
+		m.policies.status.LastError = err.Error()
+		m.logger.Errorf("Policies are not reloaded, the previous ones are kept: %v", err)
+		return
+	}
+	m.policies.status.LastError = ""
+	s := &m.policies.status
+	s.RegistryServer, s.RegistryClient = m.registryPolicies.Files()
+	s.NetworkService, s.MonitorConnection = m.connectionPolicies.Files()
+	logged := make(map[string]struct{})
+	for _, files := range [][]policies.File{s.RegistryServer, s.RegistryClient, s.NetworkService, s.MonitorConnection} {
+		for _, f := range files {
+			if _, ok := logged[f.Path]; ok {
+				continue
+			}
+			logged[f.Path] = struct{}{}
+			m.logger.Infof("Policy %s loaded, sha256: %s", f.Path, f.SHA256)
+		}
+	}
+}
+
+// policiesHandler - serves the status of the loaded policies
+func (m *manager) policiesHandler(w http.ResponseWriter, _ *http.Request) {
+	m.policies.mu.Lock()
+	status := m.policies.status
+	m.policies.mu.Unlock()
+
+	admin.WriteJSON(w, status)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"regexp"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/ratelimit"
+)
+
+// initRateLimits - creates the rate limits and the connection quotas of the clients
+func (m *manager) initRateLimits(spiffeIDConnMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]) error {
+	c := m.configuration
+	exempt, err := m.rateLimitExempt(c.RateLimitExemptPaths)
+	if err != nil {
+		return err
+	}
+	if c.RequestRateLimit > 0 || c.RegisterRateLimit > 0 || c.MaxClientConnections > 0 {
+		m.logger.Infof("Client limits: %v requests/s (burst %d), %v registers/s (burst %d), %d connections, 0 means unlimited",
+			c.RequestRateLimit, c.RequestRateBurst, c.RegisterRateLimit, c.RegisterRateBurst, c.MaxClientConnections)
+	}
+	m.rateLimits = ratelimit.NewServer(m.logger, spiffeIDConnMap,
+		ratelimit.WithRequestRate(c.RequestRateLimit, c.RequestRateBurst),
+		ratelimit.WithRegisterRate(c.RegisterRateLimit, c.RegisterRateBurst),
+		ratelimit.WithMaxConnections(c.MaxClientConnections),
+		ratelimit.WithOnThrottle(m.metrics.Throttled),
+		ratelimit.WithExempt(exempt),
+	)
+	return nil
+}
+
+// rateLimitExempt - returns function checking whether the peer is nsmgr itself, remote nsmgrs usually share its
+// SPIFFE ID, or its SPIFFE ID path matches one of the patterns
+func (m *manager) rateLimitExempt(pathPatterns []string) (func(id spiffeid.ID) bool, error) {
+	var paths []*regexp.Regexp
+	for _, s := range pathPatterns {
+		re, err := regexp.Compile("^(?:" + s + ")$")
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rate limit exempt SPIFFE ID path pattern %q", s)
+		}
+		paths = append(paths, re)
+	}
+	return func(id spiffeid.ID) bool {
+		if svid, err := m.source.GetX509SVID(); err == nil && svid.ID == id {
+			return true
+		}
+		for _, re := range paths {
+			if re.MatchString(id.Path()) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
+	registryadapter "github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/admin"
+)
+
+const (
+	// livenessService - gRPC health service which is NOT_SERVING only if nsmgr has to be restarted
+	livenessService = "liveness"
+	// readinessService - gRPC health service which is SERVING only if nsmgr can handle requests
+	readinessService = "readiness"
+
+	// Readiness checks, each of them is reported as readinessService + "." + check gRPC health service as well
+	registryCheck  = "registry"
+	forwarderCheck = "forwarder"
+	svidCheck      = "svid"
+	listenersCheck = "listeners"
+
+	// defaultReadinessCheckInterval - used if ReadinessCheckInterval is not set
+	defaultReadinessCheckInterval = 5 * time.Second
+	// svidExpiryMargin - SVID expiring sooner is considered invalid, it should have been rotated already
+	svidExpiryMargin = time.Minute
+)
+
+// readinessCheck - result of one of the readiness checks, empty Error means passed
+type readinessCheck struct {
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+}
+
+// readinessState - result of the last readiness check
+type readinessState struct {
+	mu      sync.Mutex
+	Ready   bool             `json:"ready"`
+	Reasons []string         `json:"reasons,omitempty"`
+	Checks  []readinessCheck `json:"checks,omitempty"`
+	Checked time.Time        `json:"checked"`
+}
+
+func newReadinessCheck(name string, err error) readinessCheck {
+	check := readinessCheck{Name: name}
+	if err != nil {
+		check.Error = err.Error()
+	}
+	return check
+}
+
+func (m *manager) readinessCheckInterval() time.Duration {
+	if m.configuration.ReadinessCheckInterval <= 0 {
+		return defaultReadinessCheckInterval
+	}
+	return m.configuration.ReadinessCheckInterval
+}
+
+// runReadiness - checks readiness every ReadinessCheckInterval until m.ctx is done
+func (m *manager) runReadiness(u *url.URL) {
+	ticker := time.NewTicker(m.readinessCheckInterval())
+	defer ticker.Stop()
+	for {
+		m.setReadiness(m.checkReadiness(u))
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkReadiness - runs all the readiness checks. Forwarders are found in the registry, so the forwarder check is
+// skipped if the registry is not reachable.
+func (m *manager) checkReadiness(u *url.URL) []readinessCheck {
+	forwarders, err := m.findForwarders(u)
+	checks := []readinessCheck{newReadinessCheck(registryCheck, err)}
+	if err != nil {
+		checks = append(checks, readinessCheck{Name: forwarderCheck, Error: "not checked, registry is not reachable"})
+	} else {
+		checks = append(checks, newReadinessCheck(forwarderCheck, m.checkForwarders(forwarders)))
+	}
+	return append(checks,
+		newReadinessCheck(svidCheck, m.checkSVID()),
+		newReadinessCheck(listenersCheck, m.checkListeners()))
+}
+
+// findForwarders - finds forwarders of this nsmgr the same way as sdk discoverforwarder does.
+// The query goes to the registry, so it fails if the registry is not reachable.
+func (m *manager) findForwarders(u *url.URL) ([]*registryapi.NetworkServiceEndpoint, error) {
+	ctx, cancel := context.WithTimeout(m.ctx, m.readinessCheckInterval())
+	defer cancel()
+
+	client := registryadapter.NetworkServiceEndpointServerToClient(m.mgr.NetworkServiceEndpointRegistryServer())
+	stream, err := client.Find(ctx, &registryapi.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registryapi.NetworkServiceEndpoint{
+			NetworkServiceNames: []string{m.configuration.ForwarderNetworkServiceName},
+			Url:                 u.String(),
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "registry is not reachable")
+	}
+	forwarders := registryapi.ReadNetworkServiceEndpointList(stream)
+	if err = ctx.Err(); err != nil {
+		return nil, errors.Wrap(err, "registry is not reachable")
+	}
+	return forwarders, nil
+}
+
+// checkForwarders - returns error if none of the forwarders is healthy
+func (m *manager) checkForwarders(forwarders []*registryapi.NetworkServiceEndpoint) error {
+	now := time.Now()
+	for _, nse := range forwarders {
+		if nse.GetExpirationTime() == nil || nse.GetExpirationTime().AsTime().After(now) {
+			return nil
+		}
+	}
+	return errors.Errorf("no healthy forwarder %s found", m.configuration.ForwarderNetworkServiceName)
+}
+
+func (m *manager) checkSVID() error {
+	svid, err := m.source.GetX509SVID()
+	if err != nil {
+		return errors.Wrap(err, "no valid SVID")
+	}
+	now := time.Now()
+	cert := svid.Certificates[0]
+	if now.Before(cert.NotBefore) || now.Add(svidExpiryMargin).After(cert.NotAfter) {
+		return errors.Errorf("SVID %s is not valid or expires soon: valid from %v to %v", svid.ID, cert.NotBefore, cert.NotAfter)
+	}
+	return nil
+}
+
+func (m *manager) setReadiness(checks []readinessCheck) {
+	m.readiness.mu.Lock()
+	defer m.readiness.mu.Unlock()
+
+	var reasons []string
+	for _, check := range checks {
+		status := grpc_health_v1.HealthCheckResponse_SERVING
+		if check.Error != "" {
+			reasons = append(reasons, check.Name+": "+check.Error)
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+		if m.drainer.draining() {
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+		m.health.SetServingStatus(readinessService+"."+check.Name, status)
+	}
+
+	ready := len(reasons) == 0
+	if ready != m.readiness.Ready || m.readiness.Checked.IsZero() {
+		if ready {
+			m.logger.Infof("nsmgr is ready")
+		} else {
+			m.logger.Warnf("nsmgr is not ready: %v", reasons)
+		}
+	}
+	m.readiness.Ready, m.readiness.Reasons, m.readiness.Checks, m.readiness.Checked = ready, reasons, checks, time.Now()
+
+	// Draining nsmgr must stay not ready
+	if ready && !m.drainer.draining() {
+		m.health.SetServingStatus(readinessService, grpc_health_v1.HealthCheckResponse_SERVING)
+	} else {
+		m.health.SetServingStatus(readinessService, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// setNotServing - switches all the health services except liveness to NOT_SERVING
+func (m *manager) setNotServing() {
+	m.readiness.mu.Lock()
+	defer m.readiness.mu.Unlock()
+
+	for _, service := range append(m.healthServices, "", readinessService) {
+		m.health.SetServingStatus(service, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+	for _, check := range m.readiness.Checks {
+		m.health.SetServingStatus(readinessService+"."+check.Name, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// livenessHandler - serves liveness as HTTP probe
+func (m *manager) livenessHandler(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// readinessHandler - serves readiness as HTTP probe with the reasons why nsmgr is not ready
+func (m *manager) readinessHandler(w http.ResponseWriter, _ *http.Request) {
+	m.readiness.mu.Lock()
+	state := readinessState{Ready: m.readiness.Ready && !m.drainer.draining(), Reasons: m.readiness.Reasons, Checks: m.readiness.Checks, Checked: m.readiness.Checked}
+	m.readiness.mu.Unlock()
+
+	if m.drainer.draining() {
+		state.Reasons = append(state.Reasons, "nsmgr is shutting down")
+	}
+	if !state.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	admin.WriteJSON(w, &state)
+}
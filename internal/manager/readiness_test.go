@@ -0,0 +1,159 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
+)
+
+type testSource struct {
+	notBefore, notAfter time.Time
+}
+
+func (s *testSource) GetX509SVID() (*x509svid.SVID, error) {
+	return &x509svid.SVID{
+		ID:           spiffeid.RequireFromString("spiffe://example.org/nsmgr"),
+		Certificates: []*x509.Certificate{{NotBefore: s.notBefore, NotAfter: s.notAfter}},
+	}, nil
+}
+
+func (s *testSource) GetX509BundleForTrustDomain(trustDomain spiffeid.TrustDomain) (*x509bundle.Bundle, error) {
+	return x509bundle.New(trustDomain), nil
+}
+
+func (s *testSource) Close() error {
+	return nil
+}
+
+func newTestManager(ctx context.Context, notAfter time.Time) *manager {
+	return &manager{
+		ctx:           ctx,
+		logger:        log.L(),
+		configuration: &config.Config{ForwarderNetworkServiceName: "forwarder", ReadinessCheckInterval: time.Second},
+		source:        &testSource{notBefore: time.Now().Add(-time.Hour), notAfter: notAfter},
+		health:        health.NewServer(),
+		drainer:       newDrainer(),
+	}
+}
+
+func healthStatus(t *testing.T, m *manager, service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	resp, err := m.health.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return resp.GetStatus()
+}
+
+func TestReadiness_SVID(t *testing.T) {
+	m := newTestManager(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, m.checkSVID())
+
+	m = newTestManager(context.Background(), time.Now().Add(svidExpiryMargin/2))
+	require.Error(t, m.checkSVID())
+}
+
+func TestReadiness_Forwarder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := newTestManager(ctx, time.Now().Add(time.Hour))
+	u := &url.URL{Scheme: "tcp", Host: "127.0.0.1:5001"}
+	m.mgr = nsmgr.NewServer(ctx, sandbox.GenerateTestToken, nsmgr.WithURL(u.String()))
+
+	forwarders, err := m.findForwarders(u)
+	require.NoError(t, err)
+	require.ErrorContains(t, m.checkForwarders(forwarders), "no healthy forwarder")
+
+	// Registry keeps local forwarders with nsmgr URL
+	_, err = m.mgr.NetworkServiceEndpointRegistryServer().Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "forwarder-1",
+		NetworkServiceNames: []string{"forwarder"},
+		Url:                 u.String(),
+	})
+	require.NoError(t, err)
+	forwarders, err = m.findForwarders(u)
+	require.NoError(t, err)
+	require.NoError(t, m.checkForwarders(forwarders))
+}
+
+func TestReadiness_Checks(t *testing.T) {
+	m := newTestManager(context.Background(), time.Now().Add(time.Hour))
+
+	m.setReadiness([]readinessCheck{
+		{Name: registryCheck, Error: "registry is not reachable"},
+		{Name: forwarderCheck, Error: "not checked, registry is not reachable"},
+		{Name: svidCheck},
+	})
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, healthStatus(t, m, readinessService))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, healthStatus(t, m, readinessService+"."+registryCheck))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, healthStatus(t, m, readinessService+"."+svidCheck))
+
+	m.setReadiness([]readinessCheck{
+		{Name: registryCheck},
+		{Name: forwarderCheck, Error: "no healthy forwarder forwarder found"},
+		{Name: svidCheck},
+	})
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, healthStatus(t, m, readinessService+"."+registryCheck))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, healthStatus(t, m, readinessService+"."+forwarderCheck))
+
+	w := httptest.NewRecorder()
+	m.readinessHandler(w, httptest.NewRequest(http.MethodGet, "/readyz", http.NoBody))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.Contains(t, w.Body.String(), `"forwarder: no healthy forwarder forwarder found"`)
+	require.Contains(t, w.Body.String(), `"name": "registry"`)
+}
+
+func TestReadiness_Drain(t *testing.T) {
+	m := newTestManager(context.Background(), time.Now().Add(time.Hour))
+	m.health.SetServingStatus(livenessService, grpc_health_v1.HealthCheckResponse_SERVING)
+
+	m.setReadiness(nil)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, healthStatus(t, m, readinessService))
+
+	m.setReadiness([]readinessCheck{{Name: registryCheck, Error: "registry is not reachable"}})
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, healthStatus(t, m, readinessService))
+	w := httptest.NewRecorder()
+	m.readinessHandler(w, httptest.NewRequest(http.MethodGet, "/readyz", http.NoBody))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.Contains(t, w.Body.String(), "registry is not reachable")
+
+	m.setReadiness(nil)
+	m.drainer.start()
+	m.setNotServing()
+	m.setReadiness(nil)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, healthStatus(t, m, readinessService))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, healthStatus(t, m, ""))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, healthStatus(t, m, livenessService))
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"crypto/tls"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	registrychain "github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/advertise"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/registryfailover"
+)
+
+// standalone - returns true if nsmgr uses the embedded in-memory registry instead of the external one
+func (m *manager) standalone() bool {
+	return m.configuration.Standalone || m.configuration.RegistryURL.String() == ""
+}
+
+// initRegistry - logs the registry in use and creates registry selector if failover registry urls are configured
+func (m *manager) initRegistry(tlsClientConfig *tls.Config) error {
+	if m.standalone() {
+		// sdk nsmgr keeps network services and endpoints in memory if the registry url is not passed
+		m.logger.Infof("Running standalone with embedded in-memory registry")
+		if m.configuration.RegistryURL.String() != "" || len(m.configuration.RegistryFailoverURLs) > 0 {
+			m.logger.Warnf("Registry urls are ignored in standalone mode")
+		}
+		return nil
+	}
+	m.logger.Infof("Using registry: %s", m.configuration.RegistryURL.String())
+	return m.initRegistrySelector(tlsClientConfig)
+}
+
+// initRegistrySelector - creates registry selector if failover registry urls are configured and starts health checks
+func (m *manager) initRegistrySelector(tlsClientConfig *tls.Config) error {
+	if len(m.configuration.RegistryFailoverURLs) == 0 {
+		return nil
+	}
+
+	urls := []*url.URL{&m.configuration.RegistryURL}
+	names := []string{m.configuration.RegistryURL.String()}
+	for i := range m.configuration.RegistryFailoverURLs {
+		urls = append(urls, &m.configuration.RegistryFailoverURLs[i])
+		names = append(names, m.configuration.RegistryFailoverURLs[i].String())
+	}
+
+	check := registryfailover.GRPCCheck(
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsClientConfig)),
+		grpc.WithBlock(),
+	)
+	m.registrySelector = registryfailover.NewSelector(m.logger, urls, check,
+		registryfailover.WithCheckInterval(m.configuration.RegistryHealthCheckInterval),
+		registryfailover.WithFailbackDelay(m.configuration.RegistryFailbackDelay),
+	)
+	if err := m.metrics.ObserveRegistries(urls, m.registrySelector.Active); err != nil {
+		return err
+	}
+	m.logger.Infof("Using registries in priority order: %s", strings.Join(names, ", "))
+	go m.registrySelector.Run(m.ctx)
+	return nil
+}
+
+// nseRegistryServer - returns NSE registry server element used for the registrations of the local NSEs and forwarders
+func (m *manager) nseRegistryServer() registry.NetworkServiceEndpointRegistryServer {
+	servers := []registry.NetworkServiceEndpointRegistryServer{m.rateLimits.NSEServer()}
+	if m.peerIssuer != nil {
+		servers = append(servers, peercred.NewNSERegistryServer(m.peerIssuer))
+	}
+	servers = append(servers,
+		m.registryPolicies.NSEServer(),
+		advertise.NewNSEFindServer(m.configuration.AdvertiseIPFamily),
+	)
+	if m.endpoints != nil {
+		servers = append(servers, m.endpoints.NSEServer())
+	}
+	return registrychain.NewNetworkServiceEndpointRegistryServer(servers...)
+}
+
+// nsRegistryServer - returns NS registry server element used for the registrations of the network services
+func (m *manager) nsRegistryServer() registry.NetworkServiceRegistryServer {
+	servers := []registry.NetworkServiceRegistryServer{m.rateLimits.NSServer()}
+	if m.peerIssuer != nil {
+		servers = append(servers, peercred.NewNSRegistryServer(m.peerIssuer))
+	}
+	servers = append(servers, m.registryPolicies.NSServer())
+	return registrychain.NewNetworkServiceRegistryServer(servers...)
+}
+
+// nseRegistryClient - returns NSE registry client element used for the registry calls
+func (m *manager) nseRegistryClient() registry.NetworkServiceEndpointRegistryClient {
+	clients := []registry.NetworkServiceEndpointRegistryClient{m.metrics.NSERegistryClient(), m.registryPolicies.NSEClient()}
+	if m.registrySelector != nil {
+		clients = append([]registry.NetworkServiceEndpointRegistryClient{m.registrySelector.NSEClient()}, clients...)
+	}
+	return registrychain.NewNetworkServiceEndpointRegistryClient(clients...)
+}
+
+// nsRegistryClient - returns NS registry client element used for the registry calls
+func (m *manager) nsRegistryClient() registry.NetworkServiceRegistryClient {
+	clients := []registry.NetworkServiceRegistryClient{m.metrics.NSRegistryClient(), m.registryPolicies.NSClient()}
+	if m.registrySelector != nil {
+		clients = append([]registry.NetworkServiceRegistryClient{m.registrySelector.NSClient()}, clients...)
+	}
+	return registrychain.NewNetworkServiceRegistryClient(clients...)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
+)
+
+// tokenGenerator - returns token generator using the current MaxTokenLifetime
+func (m *manager) tokenGenerator() token.GeneratorFunc {
+	return func(authInfo credentials.AuthInfo) (string, time.Time, error) {
+		return spiffejwt.TokenGeneratorFunc(m.source, time.Duration(m.maxTokenLifetime.Load()))(authInfo)
+	}
+}
+
+// reload - prepares reloadable configuration changes
+func (m *manager) reload(_ context.Context, cfg *config.Config) (apply func(), err error) {
+	if err = checkDialTimeout(cfg.DialTimeout); err != nil {
+		return nil, err
+	}
+	applyPolicies, err := m.preparePolicies(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		applyPolicies()
+		m.dialTimeout.Store(int64(cfg.DialTimeout))
+		m.maxTokenLifetime.Store(int64(cfg.MaxTokenLifetime))
+	}, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	workloadAPIAttemptTimeout = 5 * time.Second
+	workloadAPIInitialBackoff = 250 * time.Millisecond
+	workloadAPIMaxBackoff     = 5 * time.Second
+)
+
+// backoff - exponential backoff parameters for retry
+type backoff struct {
+	attemptTimeout time.Duration
+	initial        time.Duration
+	max            time.Duration
+}
+
+// retry - calls fn until it succeeds, ctx is done or timeout is expired. Timeout <= 0 means no limit except ctx.
+// Each attempt is bounded by attemptTimeout, delays between attempts grow exponentially from initial up to max.
+func retry(ctx context.Context, logger log.Logger, name string, timeout time.Duration, b backoff, fn func(ctx context.Context) error) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	delay := b.initial
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, b.attemptTimeout)
+		err := fn(attemptCtx)
+		cancel()
+		if err == nil {
+			if attempt > 1 {
+				logger.Infof("%s is available after %d attempts", name, attempt)
+			}
+			return nil
+		}
+		if ctx.Err() != nil {
+			return errors.Wrapf(err, "%s is not available after %d attempts", name, attempt)
+		}
+
+		logger.Warnf("%s is not available (attempt %d): %v, retrying in %v", name, attempt, err, delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Wrapf(err, "%s is not available after %d attempts", name, attempt)
+		case <-timer.C:
+		}
+		if delay *= 2; delay > b.max {
+			delay = b.max
+		}
+	}
+}
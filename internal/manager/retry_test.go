@@ -0,0 +1,61 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+var testBackoff = backoff{attemptTimeout: time.Second, initial: time.Millisecond, max: 4 * time.Millisecond}
+
+func TestRetry_Succeeds(t *testing.T) {
+	attempts := 0
+	err := retry(context.Background(), log.L(), "test", time.Second, testBackoff, func(context.Context) error {
+		if attempts++; attempts < 5 {
+			return errors.New("not ready")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, attempts)
+}
+
+func TestRetry_Timeout(t *testing.T) {
+	attempts := 0
+	err := retry(context.Background(), log.L(), "test", 50*time.Millisecond, testBackoff, func(context.Context) error {
+		attempts++
+		return errors.New("not ready")
+	})
+	require.ErrorContains(t, err, "not ready")
+	require.Greater(t, attempts, 1)
+}
+
+func TestRetry_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := retry(ctx, log.L(), "test", 0, testBackoff, func(ctx context.Context) error {
+		return ctx.Err()
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides nsmgr specific OpenTelemetry metrics
+package metrics
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	meterName = "github.com/networkservicemesh/cmd-nsmgr"
+
+	networkServiceKey = attribute.Key("network_service")
+	methodKey         = attribute.Key("method")
+	codeKey           = attribute.Key("code")
+	urlKey            = attribute.Key("url")
+	spiffeIDKey       = attribute.Key("spiffe_id")
+	reasonKey         = attribute.Key("reason")
+	directionKey      = attribute.Key("direction")
+)
+
+// Metrics - nsmgr specific instruments:
+//   - nsmgr_active_connections - active connections per network service
+//   - nsmgr_request_duration, nsmgr_close_duration - NetworkService Request/Close latency
+//   - nsmgr_registry_errors - failed calls to the registry
+//   - nsmgr_svid_expiry - expiry time of the nsmgr X.509 SVID as Unix time
+//   - nsmgr_registry_active - 1 for the registry in use, 0 for the other registries, see ObserveRegistries
+//   - nsmgr_throttled - calls rejected by the rate limits and the connection quotas per client SPIFFE ID
+//   - nsmgr_listener_restarts - restarts of the failed listeners
+//   - nsmgr_listener_serving - 1 for the serving listeners, 0 for the failed ones, see ObserveListeners
+//   - nsmgr_peer_rejected - mTLS peers rejected by the trust domain and SPIFFE ID path allowlists, see ObservePeerRejections
+type Metrics struct {
+	requestDuration  metric.Float64Histogram
+	closeDuration    metric.Float64Histogram
+	registryErrors   metric.Int64Counter
+	throttled        metric.Int64Counter
+	listenerRestarts metric.Int64Counter
+
+	mu          sync.Mutex
+	connections map[string]string
+}
+
+// New - creates Metrics using the global meter provider
+func New(source x509svid.Source) (*Metrics, error) {
+	meter := otel.Meter(meterName)
+	m := &Metrics{connections: make(map[string]string)}
+
+	var err error
+	if m.requestDuration, err = meter.Float64Histogram("nsmgr_request_duration",
+		metric.WithDescription("Duration of NetworkService Request calls"), metric.WithUnit("s")); err != nil {
+		return nil, errors.Wrap(err, "failed to create request duration histogram")
+	}
+	if m.closeDuration, err = meter.Float64Histogram("nsmgr_close_duration",
+		metric.WithDescription("Duration of NetworkService Close calls"), metric.WithUnit("s")); err != nil {
+		return nil, errors.Wrap(err, "failed to create close duration histogram")
+	}
+	if m.registryErrors, err = meter.Int64Counter("nsmgr_registry_errors",
+		metric.WithDescription("Number of failed calls to the registry")); err != nil {
+		return nil, errors.Wrap(err, "failed to create registry errors counter")
+	}
+	if m.throttled, err = meter.Int64Counter("nsmgr_throttled",
+		metric.WithDescription("Number of calls rejected by the rate limits and the connection quotas")); err != nil {
+		return nil, errors.Wrap(err, "failed to create throttled counter")
+	}
+	if m.listenerRestarts, err = meter.Int64Counter("nsmgr_listener_restarts",
+		metric.WithDescription("Number of restarts of the failed listeners")); err != nil {
+		return nil, errors.Wrap(err, "failed to create listener restarts counter")
+	}
+	if _, err = meter.Int64ObservableGauge("nsmgr_active_connections",
+		metric.WithDescription("Number of active connections per network service"),
+		metric.WithInt64Callback(m.observeConnections)); err != nil {
+		return nil, errors.Wrap(err, "failed to create active connections gauge")
+	}
+	if _, err = meter.Int64ObservableGauge("nsmgr_svid_expiry",
+		metric.WithDescription("Expiry time of the nsmgr X.509 SVID as Unix time"), metric.WithUnit("s"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			svid, svidErr := source.GetX509SVID()
+			if svidErr != nil {
+				return nil
+			}
+			o.Observe(svid.Certificates[0].NotAfter.Unix())
+			return nil
+		})); err != nil {
+		return nil, errors.Wrap(err, "failed to create SVID expiry gauge")
+	}
+	return m, nil
+}
+
+// ObserveRegistries - creates nsmgr_registry_active gauge for the registry urls, active returns the registry in use
+func (m *Metrics) ObserveRegistries(urls []*url.URL, active func() *url.URL) error {
+	_, err := otel.Meter(meterName).Int64ObservableGauge("nsmgr_registry_active",
+		metric.WithDescription("Registry in use: 1 for the active registry, 0 for the others"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			activeURL := active().String()
+			for _, u := range urls {
+				var value int64
+				if u.String() == activeURL {
+					value = 1
+				}
+				o.Observe(value, metric.WithAttributes(urlKey.String(u.String())))
+			}
+			return nil
+		}))
+	return errors.Wrap(err, "failed to create active registry gauge")
+}
+
+// ObserveListeners - creates nsmgr_listener_serving gauge, serving returns whether the listeners are serving by
+// their urls
+func (m *Metrics) ObserveListeners(serving func() map[string]bool) error {
+	_, err := otel.Meter(meterName).Int64ObservableGauge("nsmgr_listener_serving",
+		metric.WithDescription("Listener state: 1 for the serving listeners, 0 for the failed ones"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			for u, ok := range serving() {
+				var value int64
+				if ok {
+					value = 1
+				}
+				o.Observe(value, metric.WithAttributes(urlKey.String(u)))
+			}
+			return nil
+		}))
+	return errors.Wrap(err, "failed to create listener serving gauge")
+}
+
+// ObservePeerRejections - creates nsmgr_peer_rejected counter, rejected returns the number of rejected mTLS peers by
+// direction
+func (m *Metrics) ObservePeerRejections(rejected func() map[string]uint64) error {
+	_, err := otel.Meter(meterName).Int64ObservableCounter("nsmgr_peer_rejected",
+		metric.WithDescription("Number of mTLS peers rejected by the trust domain and SPIFFE ID path allowlists"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			for direction, count := range rejected() {
+				o.Observe(int64(count), metric.WithAttributes(directionKey.String(direction)))
+			}
+			return nil
+		}))
+	return errors.Wrap(err, "failed to create peer rejected counter")
+}
+
+// ListenerRestarted - counts the restart of the listener with url
+func (m *Metrics) ListenerRestarted(ctx context.Context, u string) {
+	m.listenerRestarts.Add(ctx, 1, metric.WithAttributes(urlKey.String(u)))
+}
+
+// Throttled - counts the call of the client spiffeID rejected for the reason
+func (m *Metrics) Throttled(ctx context.Context, spiffeID, reason string) {
+	m.throttled.Add(ctx, 1, metric.WithAttributes(spiffeIDKey.String(spiffeID), reasonKey.String(reason)))
+}
+
+func (m *Metrics) observeConnections(_ context.Context, o metric.Int64Observer) error {
+	m.mu.Lock()
+	counts := make(map[string]int64)
+	for _, networkService := range m.connections {
+		counts[networkService]++
+	}
+	m.mu.Unlock()
+
+	for networkService, count := range counts {
+		o.Observe(count, metric.WithAttributes(networkServiceKey.String(networkService)))
+	}
+	return nil
+}
+
+func (m *Metrics) connectionOpened(id, networkService string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.connections[id] = networkService
+}
+
+func (m *Metrics) connectionClosed(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.connections, id)
+}
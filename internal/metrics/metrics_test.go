@@ -0,0 +1,120 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	registrychain "github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/utils/inject/injecterror"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/metrics"
+)
+
+type testSource struct {
+	notAfter time.Time
+}
+
+func (s *testSource) GetX509SVID() (*x509svid.SVID, error) {
+	return &x509svid.SVID{Certificates: []*x509.Certificate{{NotAfter: s.notAfter}}}, nil
+}
+
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	result := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			result[m.Name] = m.Data
+		}
+	}
+	return result
+}
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+	m, err := metrics.New(&testSource{notAfter: notAfter})
+	require.NoError(t, err)
+
+	server := chain.NewNetworkServiceServer(m.NetworkServiceServer())
+	for _, id := range []string{"conn-1", "conn-2", "conn-1"} {
+		_, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{Id: id, NetworkService: "ns"},
+		})
+		require.NoError(t, err)
+	}
+	_, err = server.Close(context.Background(), &networkservice.Connection{Id: "conn-2", NetworkService: "ns"})
+	require.NoError(t, err)
+
+	client := registrychain.NewNetworkServiceEndpointRegistryClient(m.NSERegistryClient(), injecterror.NewNetworkServiceEndpointRegistryClient(
+		injecterror.WithRegisterErrorTimes(0), injecterror.WithError(errors.New("registry is not available"))))
+	_, err = client.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse"})
+	require.Error(t, err)
+
+	data := collect(t, reader)
+
+	active := data["nsmgr_active_connections"].(metricdata.Gauge[int64])
+	require.Len(t, active.DataPoints, 1)
+	require.Equal(t, int64(1), active.DataPoints[0].Value)
+
+	requests := data["nsmgr_request_duration"].(metricdata.Histogram[float64])
+	require.Equal(t, uint64(3), requests.DataPoints[0].Count)
+	closes := data["nsmgr_close_duration"].(metricdata.Histogram[float64])
+	require.Equal(t, uint64(1), closes.DataPoints[0].Count)
+
+	registryErrors := data["nsmgr_registry_errors"].(metricdata.Sum[int64])
+	require.Equal(t, int64(1), registryErrors.DataPoints[0].Value)
+
+	expiry := data["nsmgr_svid_expiry"].(metricdata.Gauge[int64])
+	require.Equal(t, notAfter.Unix(), expiry.DataPoints[0].Value)
+}
+
+func TestMetrics_PeerRejections(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	m, err := metrics.New(&testSource{notAfter: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	require.NoError(t, m.ObservePeerRejections(func() map[string]uint64 {
+		return map[string]uint64{"inbound": 2, "outbound": 0}
+	}))
+
+	rejected := collect(t, reader)["nsmgr_peer_rejected"].(metricdata.Sum[int64])
+	require.True(t, rejected.IsMonotonic)
+	values := make(map[string]int64)
+	for _, dp := range rejected.DataPoints {
+		direction, _ := dp.Attributes.Value("direction")
+		values[direction.AsString()] = dp.Value
+	}
+	require.Equal(t, map[string]int64{"inbound": 2, "outbound": 0}, values)
+}
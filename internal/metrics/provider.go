@@ -0,0 +1,62 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+type meterProvider struct {
+	ctx      context.Context
+	provider *sdkmetric.MeterProvider
+}
+
+func (p *meterProvider) Close() error {
+	return p.provider.Shutdown(p.ctx)
+}
+
+// InitPrometheus - sets global meter provider exporting metrics to the default Prometheus registerer and to the
+// additional readers, e.g. OTLP reader. sdk opentelemetry.Init supports only one metric reader, so it should be
+// called without a metric reader when Prometheus is enabled.
+func InitPrometheus(ctx context.Context, service string, readers ...sdkmetric.Reader) (io.Closer, error) {
+	exporter, err := prometheus.New()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Prometheus exporter")
+	}
+	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceNameKey.String(service)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create resource")
+	}
+
+	opts := []sdkmetric.Option{sdkmetric.WithResource(res), sdkmetric.WithReader(exporter)}
+	for _, reader := range readers {
+		if reader != nil {
+			opts = append(opts, sdkmetric.WithReader(reader))
+		}
+	}
+	provider := sdkmetric.NewMeterProvider(opts...)
+	otel.SetMeterProvider(provider)
+	return &meterProvider{ctx: ctx, provider: provider}, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+func (m *Metrics) registryError(ctx context.Context, method string, err error) {
+	if err == nil {
+		return
+	}
+	m.registryErrors.Add(ctx, 1, metric.WithAttributes(
+		methodKey.String(method),
+		codeKey.String(status.Code(err).String())))
+}
+
+type nsClient struct {
+	metrics *Metrics
+}
+
+// NSRegistryClient - returns chain element counting failed NetworkServiceRegistry calls to the registry
+func (m *Metrics) NSRegistryClient() registry.NetworkServiceRegistryClient {
+	return &nsClient{metrics: m}
+}
+
+func (c *nsClient) Register(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*registry.NetworkService, error) {
+	rv, err := next.NetworkServiceRegistryClient(ctx).Register(ctx, ns, opts...)
+	c.metrics.registryError(ctx, "NetworkServiceRegistry/Register", err)
+	return rv, err
+}
+
+func (c *nsClient) Find(ctx context.Context, query *registry.NetworkServiceQuery, opts ...grpc.CallOption) (registry.NetworkServiceRegistry_FindClient, error) {
+	rv, err := next.NetworkServiceRegistryClient(ctx).Find(ctx, query, opts...)
+	c.metrics.registryError(ctx, "NetworkServiceRegistry/Find", err)
+	return rv, err
+}
+
+func (c *nsClient) Unregister(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	rv, err := next.NetworkServiceRegistryClient(ctx).Unregister(ctx, ns, opts...)
+	c.metrics.registryError(ctx, "NetworkServiceRegistry/Unregister", err)
+	return rv, err
+}
+
+type nseClient struct {
+	metrics *Metrics
+}
+
+// NSERegistryClient - returns chain element counting failed NetworkServiceEndpointRegistry calls to the registry
+func (m *Metrics) NSERegistryClient() registry.NetworkServiceEndpointRegistryClient {
+	return &nseClient{metrics: m}
+}
+
+func (c *nseClient) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	rv, err := next.NetworkServiceEndpointRegistryClient(ctx).Register(ctx, nse, opts...)
+	c.metrics.registryError(ctx, "NetworkServiceEndpointRegistry/Register", err)
+	return rv, err
+}
+
+func (c *nseClient) Find(ctx context.Context, query *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	rv, err := next.NetworkServiceEndpointRegistryClient(ctx).Find(ctx, query, opts...)
+	c.metrics.registryError(ctx, "NetworkServiceEndpointRegistry/Find", err)
+	return rv, err
+}
+
+func (c *nseClient) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	rv, err := next.NetworkServiceEndpointRegistryClient(ctx).Unregister(ctx, nse, opts...)
+	c.metrics.registryError(ctx, "NetworkServiceEndpointRegistry/Unregister", err)
+	return rv, err
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type metricsServer struct {
+	metrics *Metrics
+}
+
+// NetworkServiceServer - returns chain element recording NetworkService metrics. It should be placed after begin,
+// so Close on connection expiration is recorded as well.
+func (m *Metrics) NetworkServiceServer() networkservice.NetworkServiceServer {
+	return &metricsServer{metrics: m}
+}
+
+func (s *metricsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	start := time.Now()
+	conn, err := next.Server(ctx).Request(ctx, request)
+	s.metrics.requestDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+		networkServiceKey.String(request.GetConnection().GetNetworkService()),
+		codeKey.String(status.Code(err).String())))
+	if err == nil {
+		s.metrics.connectionOpened(conn.GetId(), conn.GetNetworkService())
+	}
+	return conn, err
+}
+
+func (s *metricsServer) Close(ctx context.Context, conn *networkservice.Connection) (*emptypb.Empty, error) {
+	start := time.Now()
+	rv, err := next.Server(ctx).Close(ctx, conn)
+	s.metrics.closeDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+		networkServiceKey.String(conn.GetNetworkService()),
+		codeKey.String(status.Code(err).String())))
+	s.metrics.connectionClosed(conn.GetId())
+	return rv, err
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package peerauth provides mTLS peer authorization by trust domain and SPIFFE ID path allowlists
+package peerauth
+
+import (
+	"crypto/x509"
+	"regexp"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/logging"
+)
+
+const (
+	// Inbound - direction of the connections accepted by nsmgr listeners
+	Inbound = "inbound"
+	// Outbound - direction of the connections dialed by nsmgr to registry, forwarders and remote nsmgrs
+	Outbound = "outbound"
+)
+
+// Authorizer - authorizes mTLS peers by allowed trust domains and SPIFFE ID path patterns.
+// Empty allowlist allows any value.
+type Authorizer struct {
+	direction    string
+	logger       log.Logger
+	trustDomains map[spiffeid.TrustDomain]struct{}
+	paths        []*regexp.Regexp
+	rejected     atomic.Uint64
+}
+
+// NewAuthorizer - creates Authorizer for the direction. Path patterns are regular expressions matched against the
+// whole SPIFFE ID path, for example "/ns/nsm-system/sa/.*".
+func NewAuthorizer(logger log.Logger, direction string, trustDomains, pathPatterns []string) (*Authorizer, error) {
+	a := &Authorizer{
+		direction:    direction,
+		logger:       logger,
+		trustDomains: make(map[spiffeid.TrustDomain]struct{}),
+	}
+	for _, s := range trustDomains {
+		td, err := spiffeid.TrustDomainFromString(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s trust domain %q", direction, s)
+		}
+		a.trustDomains[td] = struct{}{}
+	}
+	for _, s := range pathPatterns {
+		re, err := regexp.Compile("^(?:" + s + ")$")
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s SPIFFE ID path pattern %q", direction, s)
+		}
+		a.paths = append(a.paths, re)
+	}
+	return a, nil
+}
+
+// Authorize - returns tlsconfig.Authorizer which counts and logs rejected peers
+func (a *Authorizer) Authorize() tlsconfig.Authorizer {
+	return func(id spiffeid.ID, _ [][]*x509.Certificate) error {
+		if err := a.check(id); err != nil {
+			a.rejected.Add(1)
+			a.logger.WithField(logging.SpiffeIDField, id.String()).Warnf("Rejected %s mTLS peer %q: %v", a.direction, id.String(), err)
+			return err
+		}
+		return nil
+	}
+}
+
+// Direction - returns the direction of the authorized connections: Inbound or Outbound
+func (a *Authorizer) Direction() string {
+	return a.direction
+}
+
+// Rejected - returns the number of rejected handshakes
+func (a *Authorizer) Rejected() uint64 {
+	return a.rejected.Load()
+}
+
+func (a *Authorizer) check(id spiffeid.ID) error {
+	if len(a.trustDomains) > 0 {
+		if _, ok := a.trustDomains[id.TrustDomain()]; !ok {
+			return errors.Errorf("trust domain %q is not allowed", id.TrustDomain().String())
+		}
+	}
+	if len(a.paths) == 0 {
+		return nil
+	}
+	for _, re := range a.paths {
+		if re.MatchString(id.Path()) {
+			return nil
+		}
+	}
+	return errors.Errorf("SPIFFE ID path %q is not allowed", id.Path())
+}
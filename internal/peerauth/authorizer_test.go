@@ -0,0 +1,56 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peerauth_test
+
+import (
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peerauth"
+)
+
+func TestAuthorizer(t *testing.T) {
+	a, err := peerauth.NewAuthorizer(log.L(), peerauth.Inbound, []string{"example.org", "federated.org"}, []string{"/ns/nsm-system/sa/.*", "/nsmgr"})
+	require.NoError(t, err)
+	authorize := a.Authorize()
+
+	require.NoError(t, authorize(spiffeid.RequireFromString("spiffe://example.org/ns/nsm-system/sa/forwarder"), nil))
+	require.NoError(t, authorize(spiffeid.RequireFromString("spiffe://federated.org/nsmgr"), nil))
+	require.Error(t, authorize(spiffeid.RequireFromString("spiffe://other.org/nsmgr"), nil))
+	require.Error(t, authorize(spiffeid.RequireFromString("spiffe://example.org/ns/default/sa/nse"), nil))
+	// Pattern must match the whole path
+	require.Error(t, authorize(spiffeid.RequireFromString("spiffe://example.org/nsmgr/other"), nil))
+	require.Equal(t, uint64(3), a.Rejected())
+}
+
+func TestAuthorizer_Empty(t *testing.T) {
+	a, err := peerauth.NewAuthorizer(log.L(), peerauth.Outbound, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, a.Authorize()(spiffeid.RequireFromString("spiffe://any.org/any"), nil))
+	require.Zero(t, a.Rejected())
+}
+
+func TestAuthorizer_Invalid(t *testing.T) {
+	_, err := peerauth.NewAuthorizer(log.L(), peerauth.Inbound, []string{"Not A Domain"}, nil)
+	require.Error(t, err)
+	_, err = peerauth.NewAuthorizer(log.L(), peerauth.Inbound, nil, []string{"/ns/("})
+	require.Error(t, err)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peercred
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/edwarnicke/genericsync"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Capturer - captures the peer credentials of unix socket connections on the transport handshake and adds them to
+// the context of the calls made over the connections
+type Capturer struct {
+	creds genericsync.Map[net.Addr, *Cred]
+}
+
+// NewCapturer - creates Capturer
+func NewCapturer() *Capturer {
+	return &Capturer{}
+}
+
+// TransportCredentials - returns server transport credentials capturing the peer credentials before the handshake
+// with creds
+func (c *Capturer) TransportCredentials(creds credentials.TransportCredentials) credentials.TransportCredentials {
+	return &captureCredentials{TransportCredentials: creds, capturer: c}
+}
+
+// UnaryServerInterceptor - returns interceptor adding the captured peer credentials to the call context
+func (c *Capturer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(c.withCred(ctx), req)
+	}
+}
+
+// StreamServerInterceptor - returns interceptor adding the captured peer credentials to the stream context
+func (c *Capturer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := c.withCred(ss.Context())
+		if ctx == ss.Context() {
+			return handler(srv, ss)
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// withCred - returns ctx with the peer credentials of the connection of the call if they are captured. The
+// connections are identified by the peer address, grpcfd makes it unique for each unix socket connection.
+func (c *Capturer) withCred(ctx context.Context) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ctx
+	}
+	if cred, ok := c.creds.Load(p.Addr); ok {
+		return WithCred(ctx, cred)
+	}
+	return ctx
+}
+
+type captureCredentials struct {
+	credentials.TransportCredentials
+	capturer *Capturer
+}
+
+func (c *captureCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	cred, credErr := FromConn(rawConn)
+	conn, authInfo, err := c.TransportCredentials.ServerHandshake(rawConn)
+	if err != nil || credErr != nil || conn.RemoteAddr() == nil {
+		return conn, authInfo, err
+	}
+	addr := conn.RemoteAddr()
+	c.capturer.creds.Store(addr, cred)
+	return &capturedConn{Conn: conn, release: func() { c.capturer.creds.Delete(addr) }}, authInfo, nil
+}
+
+func (c *captureCredentials) Clone() credentials.TransportCredentials {
+	return &captureCredentials{TransportCredentials: c.TransportCredentials.Clone(), capturer: c.capturer}
+}
+
+// capturedConn - releases the captured credentials when the connection is closed
+type capturedConn struct {
+	net.Conn
+	release   func()
+	closeOnce sync.Once
+}
+
+func (c *capturedConn) Close() error {
+	c.closeOnce.Do(c.release)
+	return c.Conn.Close()
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package peercred_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edwarnicke/grpcfd"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+)
+
+func TestCapturer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	capturer := peercred.NewCapturer()
+	var captured *peercred.Cred
+	server := grpc.NewServer(
+		grpc.Creds(capturer.TransportCredentials(grpcfd.TransportCredentials(insecure.NewCredentials()))),
+		grpc.ChainUnaryInterceptor(capturer.UnaryServerInterceptor(),
+			func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				captured, _ = peercred.FromContext(ctx)
+				return handler(ctx, req)
+			}),
+	)
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	defer server.Stop()
+
+	socket := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	go func() { _ = server.Serve(ln) }()
+
+	cc, err := grpc.NewClient("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	_, err = grpc_health_v1.NewHealthClient(cc).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, captured)
+	require.Equal(t, int32(os.Getpid()), captured.PID)
+	require.Equal(t, uint32(os.Getuid()), captured.UID)
+}
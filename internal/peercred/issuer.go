@@ -0,0 +1,94 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peercred
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/spire"
+)
+
+// Issuer - issues the tokens of the callers connected over unix sockets without SPIFFE ID. nsmgr vouches for such
+// callers, so the policies of nsmgr and of the next hops check their path segments the same way as the segments of
+// the callers with SVIDs.
+type Issuer struct {
+	source           x509svid.Source
+	name             string
+	maxTokenLifetime func() time.Duration
+}
+
+// NewIssuer - creates Issuer signing the tokens with the SVID from source. The peers get SPIFFE IDs of nsmgr with
+// name and their UID appended.
+func NewIssuer(source x509svid.Source, name string, maxTokenLifetime func() time.Duration) *Issuer {
+	return &Issuer{
+		source:           source,
+		name:             name,
+		maxTokenLifetime: maxTokenLifetime,
+	}
+}
+
+// ID - returns SPIFFE ID the tokens of the peer are issued for, e.g. spiffe://example.org/nsmgr/node-1/uid/1000
+func (i *Issuer) ID(cred *Cred) (spiffeid.ID, error) {
+	svid, err := i.source.GetX509SVID()
+	if err != nil {
+		return spiffeid.ID{}, errors.Wrap(err, "failed to get SVID of nsmgr")
+	}
+	id, err := svid.ID.AppendSegments(i.name, "uid", strconv.FormatUint(uint64(cred.UID), 10))
+	return id, errors.Wrapf(err, "failed to create SPIFFE ID of the peer %s", cred.String())
+}
+
+// Token - returns token of the peer with nsmgr as the audience, signed by nsmgr
+func (i *Issuer) Token(cred *Cred) (string, time.Time, error) {
+	svid, err := i.source.GetX509SVID()
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "failed to get SVID of nsmgr")
+	}
+	id, err := i.ID(cred)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	expireTime := time.Now().Add(i.maxTokenLifetime())
+	if svid.Certificates[0].NotAfter.Before(expireTime) {
+		expireTime = svid.Certificates[0].NotAfter
+	}
+	claims := jwt.RegisteredClaims{
+		Subject:   id.String(),
+		Audience:  []string{svid.ID.String()},
+		ExpiresAt: jwt.NewNumericDate(expireTime),
+	}
+	tok, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(svid.PrivateKey)
+	return tok, expireTime, errors.Wrapf(err, "failed to create token of the peer %s", cred.String())
+}
+
+// unauthenticated - returns the peer credentials of the call if the caller has no SPIFFE ID
+func unauthenticated(ctx context.Context) (*Cred, bool) {
+	cred, ok := FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	if _, err := spire.PeerSpiffeIDFromContext(ctx); err == nil {
+		return nil, false
+	}
+	return cred, true
+}
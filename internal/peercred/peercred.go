@@ -0,0 +1,47 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package peercred provides credentials of the peer process of unix socket connections
+package peercred
+
+import (
+	"context"
+	"fmt"
+)
+
+type contextKey struct{}
+
+// Cred - credentials of the peer process at the time it connected
+type Cred struct {
+	PID int32  `json:"pid"`
+	UID uint32 `json:"uid"`
+	GID uint32 `json:"gid"`
+}
+
+// WithCred - returns context with the peer credentials of the call
+func WithCred(ctx context.Context, cred *Cred) context.Context {
+	return context.WithValue(ctx, contextKey{}, cred)
+}
+
+// FromContext - returns the peer credentials of the call made over a unix socket, see Capturer
+func FromContext(ctx context.Context) (*Cred, bool) {
+	cred, ok := ctx.Value(contextKey{}).(*Cred)
+	return cred, ok
+}
+
+func (c *Cred) String() string {
+	return fmt.Sprintf("pid=%d uid=%d gid=%d", c.PID, c.UID, c.GID)
+}
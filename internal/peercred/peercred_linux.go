@@ -0,0 +1,50 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package peercred
+
+import (
+	"net"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// FromConn - returns SO_PEERCRED credentials of the unix socket connection
+func FromConn(conn net.Conn) (*Cred, error) {
+	sc, ok := conn.(syscall.Conn)
+	if !ok || conn.LocalAddr().Network() != "unix" {
+		return nil, errors.Errorf("%T is not a unix socket connection", conn)
+	}
+	rawConn, err := sc.SyscallConn()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get raw connection")
+	}
+	var ucred *syscall.Ucred
+	var sockErr error
+	if err = rawConn.Control(func(fd uintptr) {
+		ucred, sockErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to get peer credentials")
+	}
+	if sockErr != nil {
+		return nil, errors.Wrap(sockErr, "failed to get peer credentials")
+	}
+	return &Cred{PID: ucred.Pid, UID: ucred.Uid, GID: ucred.Gid}, nil
+}
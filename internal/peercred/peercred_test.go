@@ -0,0 +1,68 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package peercred_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+)
+
+func TestFromConn(t *testing.T) {
+	ln, err := net.Listen("unix", filepath.Join(t.TempDir(), "test.sock"))
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+
+	client, err := net.Dial("unix", ln.Addr().String())
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	cred, err := peercred.FromConn(conn)
+	require.NoError(t, err)
+	require.Equal(t, int32(os.Getpid()), cred.PID)
+	require.Equal(t, uint32(os.Getuid()), cred.UID)
+	require.Equal(t, uint32(os.Getgid()), cred.GID)
+}
+
+func TestFromConn_TCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+
+	client, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	_, err = peercred.FromConn(conn)
+	require.Error(t, err)
+}
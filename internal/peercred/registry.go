@@ -0,0 +1,116 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peercred
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type nseServer struct {
+	issuer *Issuer
+}
+
+// NewNSERegistryServer - NSE registry chain element replacing the token and the path ID of the callers connected
+// over unix sockets without SPIFFE ID with the ones issued by issuer, it should precede the authorize chain elements
+func NewNSERegistryServer(issuer *Issuer) registry.NetworkServiceEndpointRegistryServer {
+	return &nseServer{issuer: issuer}
+}
+
+func (s *nseServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	var err error
+	if nse.PathIds, err = s.issuer.issuePath(ctx, nse.GetPathIds()); err != nil {
+		return nil, err
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *nseServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *nseServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*emptypb.Empty, error) {
+	var err error
+	if nse.PathIds, err = s.issuer.issuePath(ctx, nse.GetPathIds()); err != nil {
+		return nil, err
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+type nsServer struct {
+	issuer *Issuer
+}
+
+// NewNSRegistryServer - NS registry chain element replacing the token and the path ID of the callers connected over
+// unix sockets without SPIFFE ID with the ones issued by issuer, it should precede the authorize chain elements
+func NewNSRegistryServer(issuer *Issuer) registry.NetworkServiceRegistryServer {
+	return &nsServer{issuer: issuer}
+}
+
+func (s *nsServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	var err error
+	if ns.PathIds, err = s.issuer.issuePath(ctx, ns.GetPathIds()); err != nil {
+		return nil, err
+	}
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *nsServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *nsServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*emptypb.Empty, error) {
+	var err error
+	if ns.PathIds, err = s.issuer.issuePath(ctx, ns.GetPathIds()); err != nil {
+		return nil, err
+	}
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+// issuePath - replaces the token of the caller path segment of the registry call and its path ID with the ones
+// issued for the caller without SPIFFE ID
+func (i *Issuer) issuePath(ctx context.Context, pathIDs []string) ([]string, error) {
+	cred, ok := unauthenticated(ctx)
+	if !ok {
+		return pathIDs, nil
+	}
+	path := grpcmetadata.PathFromContext(ctx)
+	if path.Index == 0 || int(path.Index) > len(path.PathSegments) {
+		return pathIDs, nil
+	}
+	tok, _, err := i.Token(cred)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	id, err := i.ID(cred)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	prev := int(path.Index) - 1
+	path.PathSegments[prev].Token = tok
+	if prev < len(pathIDs) {
+		pathIDs[prev] = id.String()
+	}
+	return pathIDs, nil
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peercred
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// PIDField, UIDField, GIDField - log fields with the peer credentials of the call
+	PIDField = "peer_pid"
+	UIDField = "peer_uid"
+	GIDField = "peer_gid"
+
+	// anyService - allows any network service for the UID
+	anyService = "*"
+)
+
+// ParseUIDServices - parses network services allowed by UID given as "UID:services", services are separated by
+// "|", "*" allows any network service, e.g. "1000:icmp-responder|vl3"
+func ParseUIDServices(values []string) (map[uint32][]string, error) {
+	rv := make(map[uint32][]string, len(values))
+	for _, value := range values {
+		key, services, ok := strings.Cut(value, ":")
+		if !ok || services == "" {
+			return nil, errors.Errorf("invalid UID network services %q, expected UID:services", value)
+		}
+		uid, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return nil, errors.Errorf("invalid UID %q", key)
+		}
+		rv[uint32(uid)] = append(rv[uint32(uid)], strings.Split(services, "|")...)
+	}
+	return rv, nil
+}
+
+type server struct {
+	logger      log.Logger
+	uidServices map[uint32][]string
+	issuer      *Issuer
+}
+
+// NewServer - chain element adding the peer credentials of the callers connected over unix sockets to the logger of
+// the call. If uidServices is not empty, such callers without SPIFFE ID can request only the network services of
+// their UID, the callers with SPIFFE ID are authorized by the policies. The tokens of the callers without SPIFFE ID
+// are issued by issuer, so it should precede the authorize chain elements.
+func NewServer(logger log.Logger, uidServices map[uint32][]string, issuer *Issuer) networkservice.NetworkServiceServer {
+	return &server{
+		logger:      logger,
+		uidServices: uidServices,
+		issuer:      issuer,
+	}
+}
+
+func (s *server) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	cred, ok := FromContext(ctx)
+	if !ok {
+		return next.Server(ctx).Request(ctx, request)
+	}
+	ctx = withLogFields(ctx, cred)
+	if _, ok = unauthenticated(ctx); ok {
+		if err := s.authorize(cred, request.GetConnection().GetNetworkService()); err != nil {
+			return nil, err
+		}
+		if err := s.issueToken(cred, request.GetConnection()); err != nil {
+			return nil, err
+		}
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *server) Close(ctx context.Context, conn *networkservice.Connection) (*emptypb.Empty, error) {
+	if cred, ok := FromContext(ctx); ok {
+		ctx = withLogFields(ctx, cred)
+	}
+	if cred, ok := unauthenticated(ctx); ok {
+		if err := s.issueToken(cred, conn); err != nil {
+			return nil, err
+		}
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// issueToken - replaces the token of the caller path segment with the token issued by nsmgr
+func (s *server) issueToken(cred *Cred, conn *networkservice.Connection) error {
+	prev := conn.GetPrevPathSegment()
+	if prev == nil {
+		return nil
+	}
+	tok, expireTime, err := s.issuer.Token(cred)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	prev.Token, prev.Expires = tok, timestamppb.New(expireTime.Local())
+	return nil
+}
+
+func (s *server) authorize(cred *Cred, networkService string) error {
+	if len(s.uidServices) == 0 {
+		return nil
+	}
+	for _, service := range s.uidServices[cred.UID] {
+		if service == anyService || service == networkService {
+			return nil
+		}
+	}
+	s.logger.WithField(PIDField, cred.PID).WithField(UIDField, cred.UID).WithField(GIDField, cred.GID).
+		Warnf("Rejected request of network service %q by local peer %s without SPIFFE ID", networkService, cred.String())
+	return status.Errorf(codes.PermissionDenied, "network service %q is not allowed for UID %d", networkService, cred.UID)
+}
+
+func withLogFields(ctx context.Context, cred *Cred) context.Context {
+	return log.WithLog(ctx, log.FromContext(ctx).WithField(PIDField, cred.PID).WithField(UIDField, cred.UID).WithField(GIDField, cred.GID))
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peercred_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	registrynext "github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+)
+
+func request(networkService string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{NetworkService: networkService},
+	}
+}
+
+type testSource struct {
+	svid *x509svid.SVID
+}
+
+func (s *testSource) GetX509SVID() (*x509svid.SVID, error) {
+	return s.svid, nil
+}
+
+func newTestIssuer(t *testing.T) (*peercred.Issuer, *x509svid.SVID) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	id := spiffeid.RequireFromString("spiffe://example.org/nsmgr")
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{id.URL()},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	svid := &x509svid.SVID{ID: id, Certificates: []*x509.Certificate{cert}, PrivateKey: key}
+	return peercred.NewIssuer(&testSource{svid: svid}, "node-1", func() time.Duration { return time.Minute }), svid
+}
+
+func parseToken(t *testing.T, svid *x509svid.SVID, tok string) *jwt.RegisteredClaims {
+	claims := new(jwt.RegisteredClaims)
+	_, err := jwt.ParseWithClaims(tok, claims, func(*jwt.Token) (interface{}, error) {
+		return svid.Certificates[0].PublicKey, nil
+	})
+	require.NoError(t, err)
+	return claims
+}
+
+func TestParseUIDServices(t *testing.T) {
+	uidServices, err := peercred.ParseUIDServices([]string{"1000:icmp-responder|vl3", "0:*"})
+	require.NoError(t, err)
+	require.Equal(t, map[uint32][]string{1000: {"icmp-responder", "vl3"}, 0: {"*"}}, uidServices)
+
+	_, err = peercred.ParseUIDServices([]string{"root:*"})
+	require.Error(t, err)
+	_, err = peercred.ParseUIDServices([]string{"1000"})
+	require.Error(t, err)
+}
+
+func TestServer_UIDServices(t *testing.T) {
+	uidServices, err := peercred.ParseUIDServices([]string{"1000:icmp-responder", "0:*"})
+	require.NoError(t, err)
+	server := next.NewNetworkServiceServer(peercred.NewServer(log.L(), uidServices, nil))
+
+	ctx := peercred.WithCred(context.Background(), &peercred.Cred{PID: 1, UID: 1000, GID: 1000})
+	_, err = server.Request(ctx, request("icmp-responder"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, request("vl3"))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	ctx = peercred.WithCred(context.Background(), &peercred.Cred{PID: 1, UID: 0, GID: 0})
+	_, err = server.Request(ctx, request("vl3"))
+	require.NoError(t, err)
+
+	ctx = peercred.WithCred(context.Background(), &peercred.Cred{PID: 1, UID: 2000, GID: 2000})
+	_, err = server.Request(ctx, request("icmp-responder"))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Calls not made over unix sockets are not restricted
+	_, err = server.Request(context.Background(), request("vl3"))
+	require.NoError(t, err)
+}
+
+func TestServer_NoUIDServices(t *testing.T) {
+	server := next.NewNetworkServiceServer(peercred.NewServer(log.L(), nil, nil))
+	ctx := peercred.WithCred(context.Background(), &peercred.Cred{PID: 1, UID: 2000, GID: 2000})
+	_, err := server.Request(ctx, request("icmp-responder"))
+	require.NoError(t, err)
+}
+
+func TestServer_IssueToken(t *testing.T) {
+	issuer, svid := newTestIssuer(t)
+	server := next.NewNetworkServiceServer(peercred.NewServer(log.L(), nil, issuer))
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			NetworkService: "icmp-responder",
+			Path: &networkservice.Path{
+				Index:        1,
+				PathSegments: []*networkservice.PathSegment{{Name: "nsc", Token: "nsc-token"}, {Name: "nsmgr"}},
+			},
+		},
+	}
+	ctx := peercred.WithCred(context.Background(), &peercred.Cred{PID: 1, UID: 1000, GID: 1000})
+	conn, err := server.Request(ctx, request)
+	require.NoError(t, err)
+
+	claims := parseToken(t, svid, conn.GetPath().GetPathSegments()[0].GetToken())
+	require.Equal(t, "spiffe://example.org/nsmgr/node-1/uid/1000", claims.Subject)
+	require.Equal(t, jwt.ClaimStrings{"spiffe://example.org/nsmgr"}, claims.Audience)
+	require.Equal(t, claims.ExpiresAt.Unix(), conn.GetPath().GetPathSegments()[0].GetExpires().AsTime().Unix())
+
+	// Tokens of the calls not made over unix sockets are not replaced
+	request.Connection.Path.PathSegments[0].Token = "nsc-token"
+	conn, err = server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, "nsc-token", conn.GetPath().GetPathSegments()[0].GetToken())
+}
+
+func TestNSERegistryServer_IssuePath(t *testing.T) {
+	issuer, svid := newTestIssuer(t)
+	server := registrynext.NewNetworkServiceEndpointRegistryServer(peercred.NewNSERegistryServer(issuer))
+
+	path := &grpcmetadata.Path{
+		Index:        1,
+		PathSegments: []*grpcmetadata.PathSegment{{Token: "nse-token"}, {Token: "nsmgr-token"}},
+	}
+	ctx := grpcmetadata.PathWithContext(context.Background(), path)
+	ctx = peercred.WithCred(ctx, &peercred.Cred{PID: 1, UID: 1000, GID: 1000})
+	nse, err := server.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:    "nse-1",
+		PathIds: []string{"spiffe://example.org/nse", "spiffe://example.org/nsmgr"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "spiffe://example.org/nsmgr/node-1/uid/1000", nse.GetPathIds()[0])
+	require.Equal(t, nse.GetPathIds()[0], parseToken(t, svid, path.PathSegments[0].Token).Subject)
+	require.Equal(t, "nsmgr-token", path.PathSegments[1].Token)
+}
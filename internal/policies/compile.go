@@ -0,0 +1,101 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policies
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+// embedded - hash reported for the policies embedded into sdk, they can't be changed at runtime
+const embedded = "embedded"
+
+// File - loaded policy file
+type File struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// Compile - finds policy files by the masks the same way as sdk does and compiles them. sdk compiles policies
+// lazily on the first check, so a broken policy would fail every call instead of failing the load.
+func Compile(masks ...string) ([]File, error) {
+	return load(true, masks...)
+}
+
+// checkUnchanged - sdk chain elements read the policy files by themselves, so the files are hashed once more after
+// the chain elements are created. Equal hashes mean the chain elements got the same content that was compiled.
+func checkUnchanged(compiled []File, masks ...string) error {
+	files, err := load(false, masks...)
+	if err != nil {
+		return err
+	}
+	if !slices.Equal(compiled, files) {
+		return errors.Errorf("policies %v are changed while loading", masks)
+	}
+	return nil
+}
+
+func load(compileSources bool, masks ...string) ([]File, error) {
+	policies, err := opa.PoliciesByFileMask(masks...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load policies %v", masks)
+	}
+	var files []File
+	for _, p := range policies {
+		// #nosec
+		source, readErr := os.ReadFile(p.Name())
+		if readErr != nil {
+			files = append(files, File{Path: p.Name(), SHA256: embedded})
+			continue
+		}
+		if compileSources {
+			if err := compile(p.Name(), string(source)); err != nil {
+				return nil, err
+			}
+		}
+		sum := sha256.Sum256(source)
+		files = append(files, File{Path: p.Name(), SHA256: hex.EncodeToString(sum[:])})
+	}
+	return files, nil
+}
+
+func compile(name, source string) error {
+	const pkg = "package"
+	var module string
+	for _, line := range strings.Split(strings.TrimSpace(source), "\n") {
+		if strings.HasPrefix(line, pkg) {
+			module = strings.TrimSpace(line[len(pkg):])
+			break
+		}
+	}
+	if module == "" {
+		return errors.Errorf("failed to compile policy %s: missed package", name)
+	}
+	_, err := rego.New(
+		rego.Query(strings.Join([]string{"data", module, "valid"}, ".")),
+		rego.Module(module, source)).PrepareForEval(context.Background())
+	return errors.Wrapf(err, "failed to compile policy %s", name)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policies_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/policies"
+)
+
+func TestCompile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "deny.rego"), []byte(denyAll), 0o600))
+
+	files, err := policies.Compile(filepath.Join(dir, ".*.rego"), "etc/nsm/opa/common/tokens_valid.rego")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Equal(t, filepath.Join(dir, "deny.rego"), files[0].Path)
+	require.Len(t, files[0].SHA256, 64)
+	require.Equal(t, "embedded", files[1].SHA256)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.rego"), []byte("package test\n\nvalid {"), 0o600))
+	_, err = policies.Compile(filepath.Join(dir, ".*.rego"))
+	require.ErrorContains(t, err, "broken.rego")
+}
+
+func TestWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	var changes atomic.Int32
+	w, err := policies.NewWatcher(ctx, func() { changes.Add(1) })
+	require.NoError(t, err)
+	require.NoError(t, w.Watch(filepath.Join(dir, ".*.rego"), "etc/nsm/opa/common/.*.rego"))
+
+	// Several writes of one update are reported once
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.rego"), []byte(denyAll), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.rego"), []byte(denyAll), 0o600))
+	require.Eventually(t, func() bool { return changes.Load() == 1 }, time.Second, 10*time.Millisecond)
+	require.Never(t, func() bool { return changes.Load() > 1 }, 300*time.Millisecond, 10*time.Millisecond)
+
+	require.NoError(t, w.Watch())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.rego"), []byte(denyAll), 0o600))
+	require.Never(t, func() bool { return changes.Load() > 1 }, 300*time.Millisecond, 10*time.Millisecond)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policies
+
+import (
+	"sync"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	authmonitor "github.com/networkservicemesh/sdk/pkg/tools/monitorconnection/authorize"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+// Connection - NetworkService and MonitorConnection authorize chain elements of nsmgr
+type Connection struct {
+	networkServiceServer    networkServiceServer
+	monitorConnectionServer monitorConnectionServer
+
+	spiffeIDConnMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]
+
+	networkServiceFiles    []File
+	monitorConnectionFiles []File
+
+	mu sync.Mutex
+}
+
+// NewConnection - creates NetworkService and MonitorConnection authorize chain elements with the given policies.
+// spiffeIDConnMap is shared by both of them, so it is preserved on Update.
+func NewConnection(spiffeIDConnMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]], networkServicePolicies, monitorConnectionPolicies []string) (*Connection, error) {
+	c := &Connection{spiffeIDConnMap: spiffeIDConnMap}
+	if err := c.Update(networkServicePolicies, monitorConnectionPolicies); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Update - (re)loads policies of the chain elements. Policies are replaced only if all of them are loaded successfully.
+func (c *Connection) Update(networkServicePolicies, monitorConnectionPolicies []string) error {
+	apply, err := c.Prepare(networkServicePolicies, monitorConnectionPolicies)
+	if err != nil {
+		return err
+	}
+	apply()
+	return nil
+}
+
+// Prepare - loads and compiles policies, returned function replaces policies of the chain elements with them
+func (c *Connection) Prepare(networkServicePolicies, monitorConnectionPolicies []string) (apply func(), err error) {
+	networkServiceFiles, err := Compile(networkServicePolicies...)
+	if err != nil {
+		return nil, err
+	}
+	monitorConnectionFiles, err := Compile(monitorConnectionPolicies...)
+	if err != nil {
+		return nil, err
+	}
+	monitorPolicies, err := opa.PoliciesByFileMask(monitorConnectionPolicies...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load policies %v", monitorConnectionPolicies)
+	}
+	var policyList []authmonitor.Policy
+	for _, p := range monitorPolicies {
+		policyList = append(policyList, p)
+	}
+
+	networkServiceServer := authorize.NewServer(
+		authorize.WithPolicies(networkServicePolicies...),
+		authorize.WithSpiffeIDConnectionMap(c.spiffeIDConnMap))
+	monitorConnectionServer := authmonitor.NewMonitorConnectionServer(
+		authmonitor.WithPolicies(policyList...),
+		authmonitor.WithSpiffeIDConnectionMap(c.spiffeIDConnMap))
+
+	if err := checkUnchanged(networkServiceFiles, networkServicePolicies...); err != nil {
+		return nil, err
+	}
+	if err := checkUnchanged(monitorConnectionFiles, monitorConnectionPolicies...); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.networkServiceFiles, c.monitorConnectionFiles = networkServiceFiles, monitorConnectionFiles
+		c.networkServiceServer.store(networkServiceServer)
+		c.monitorConnectionServer.store(monitorConnectionServer)
+	}, nil
+}
+
+// Files - returns the loaded NetworkService and MonitorConnection policy files
+func (c *Connection) Files() (networkServiceFiles, monitorConnectionFiles []File) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.networkServiceFiles, c.monitorConnectionFiles
+}
+
+// NetworkServiceServer - returns authorize NetworkServiceServer
+func (c *Connection) NetworkServiceServer() networkservice.NetworkServiceServer {
+	return &c.networkServiceServer
+}
+
+// MonitorConnectionServer - returns authorize MonitorConnectionServer
+func (c *Connection) MonitorConnectionServer() networkservice.MonitorConnectionServer {
+	return &c.monitorConnectionServer
+}
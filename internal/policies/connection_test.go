@@ -0,0 +1,101 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policies_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/policies"
+)
+
+const denyAll = `package test
+
+default valid = false
+`
+
+func TestConnection_Update(t *testing.T) {
+	spiffeIDConnMap := genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]{}
+	c, err := policies.NewConnection(&spiffeIDConnMap, nil, nil)
+	require.NoError(t, err)
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:   "id",
+			Path: &networkservice.Path{PathSegments: []*networkservice.PathSegment{{Id: "id"}}},
+		},
+	}
+	_, err = c.NetworkServiceServer().Request(context.Background(), request)
+	require.NoError(t, err)
+
+	policyFile := filepath.Join(t.TempDir(), "deny.rego")
+	require.NoError(t, os.WriteFile(policyFile, []byte(denyAll), 0o600))
+	require.NoError(t, c.Update([]string{policyFile}, nil))
+
+	// Policies are checked only for remote peers
+	ctx := peer.NewContext(context.Background(), &peer.Peer{})
+	_, err = c.NetworkServiceServer().Request(ctx, request)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestConnection_Prepare(t *testing.T) {
+	spiffeIDConnMap := genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]{}
+	c, err := policies.NewConnection(&spiffeIDConnMap, nil, nil)
+	require.NoError(t, err)
+
+	policyFile := filepath.Join(t.TempDir(), "deny.rego")
+	require.NoError(t, os.WriteFile(policyFile, []byte(denyAll), 0o600))
+	apply, err := c.Prepare([]string{policyFile}, nil)
+	require.NoError(t, err)
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:   "id",
+			Path: &networkservice.Path{PathSegments: []*networkservice.PathSegment{{Id: "id"}}},
+		},
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{})
+
+	// Prepared policies are not used until they are applied
+	_, err = c.NetworkServiceServer().Request(ctx, request)
+	require.NoError(t, err)
+	networkServiceFiles, _ := c.Files()
+	require.Empty(t, networkServiceFiles)
+
+	apply()
+	_, err = c.NetworkServiceServer().Request(ctx, request)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	networkServiceFiles, _ = c.Files()
+	require.Len(t, networkServiceFiles, 1)
+}
+
+func TestConnection_InvalidPolicies(t *testing.T) {
+	spiffeIDConnMap := genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]{}
+	_, err := policies.NewConnection(&spiffeIDConnMap, nil, []string{"etc/nsm/opa/monitor/(.rego"})
+	require.Error(t, err)
+}
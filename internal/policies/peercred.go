@@ -0,0 +1,52 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policies
+
+import (
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/types"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+)
+
+// PeerCredBuiltin - name of the builtin returning {"pid", "uid", "gid"} of the caller connected over a unix socket,
+// it is undefined for the other callers. sdk builds the policy input only from the path and the peer certificate,
+// so the peer credentials are provided to the policies by the builtin.
+const PeerCredBuiltin = "nsmgr.peer_cred"
+
+func init() {
+	rego.RegisterBuiltinDyn(&rego.Function{
+		Name: PeerCredBuiltin,
+		Decl: types.NewFunction(nil, types.NewObject([]*types.StaticProperty{
+			types.NewStaticProperty("pid", types.N),
+			types.NewStaticProperty("uid", types.N),
+			types.NewStaticProperty("gid", types.N),
+		}, nil)),
+		Nondeterministic: true,
+	}, func(bctx rego.BuiltinContext, _ []*ast.Term) (*ast.Term, error) {
+		cred, ok := peercred.FromContext(bctx.Context)
+		if !ok {
+			return nil, nil
+		}
+		return ast.ObjectTerm(
+			ast.Item(ast.StringTerm("pid"), ast.IntNumberTerm(int(cred.PID))),
+			ast.Item(ast.StringTerm("uid"), ast.UIntNumberTerm(uint64(cred.UID))),
+			ast.Item(ast.StringTerm("gid"), ast.UIntNumberTerm(uint64(cred.GID))),
+		), nil
+	})
+}
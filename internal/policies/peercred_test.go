@@ -0,0 +1,65 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policies_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/peercred"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/policies"
+)
+
+const peerCredServerPolicies = "../../etc/nsmgr/opa/server/.*.rego"
+
+func TestPeerCredBuiltin(t *testing.T) {
+	files, err := policies.Compile(peerCredServerPolicies)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	spiffeIDConnMap := genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]{}
+	c, err := policies.NewConnection(&spiffeIDConnMap, []string{peerCredServerPolicies}, nil)
+	require.NoError(t, err)
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Path: &networkservice.Path{
+				Index:        1,
+				PathSegments: []*networkservice.PathSegment{{Id: "nsc"}, {Id: "id"}},
+			},
+		},
+	}
+
+	// Callers without certificate are allowed only if they are connected over unix sockets
+	ctx := peer.NewContext(context.Background(), &peer.Peer{})
+	_, err = c.NetworkServiceServer().Request(ctx, request)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	ctx = peercred.WithCred(ctx, &peercred.Cred{PID: 1, UID: 1000, GID: 1000})
+	_, err = c.NetworkServiceServer().Request(ctx, request)
+	require.NoError(t, err)
+}
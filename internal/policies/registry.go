@@ -0,0 +1,140 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policies provides authorize chain elements whose OPA policies can be replaced at runtime
+package policies
+
+import (
+	"sync"
+
+	"github.com/edwarnicke/genericsync"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	registryauthorize "github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+)
+
+// Registry - registry authorize chain elements of nsmgr
+type Registry struct {
+	nsServer  nsServer
+	nseServer nseServer
+	nsClient  nsClient
+	nseClient nseClient
+
+	nsServerPathIDs  genericsync.Map[string, []string]
+	nseServerPathIDs genericsync.Map[string, []string]
+	nsClientPathIDs  genericsync.Map[string, []string]
+	nseClientPathIDs genericsync.Map[string, []string]
+
+	serverFiles []File
+	clientFiles []File
+
+	mu sync.Mutex
+}
+
+// NewRegistry - creates registry authorize chain elements with the given policies
+func NewRegistry(serverPolicies, clientPolicies []string) (*Registry, error) {
+	r := new(Registry)
+	if err := r.Update(serverPolicies, clientPolicies); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Update - (re)loads policies of the chain elements. Policies are replaced only if all of them are loaded successfully.
+func (r *Registry) Update(serverPolicies, clientPolicies []string) error {
+	apply, err := r.Prepare(serverPolicies, clientPolicies)
+	if err != nil {
+		return err
+	}
+	apply()
+	return nil
+}
+
+// Prepare - loads and compiles policies, returned function replaces policies of the chain elements with them
+func (r *Registry) Prepare(serverPolicies, clientPolicies []string) (apply func(), err error) {
+	serverFiles, err := Compile(serverPolicies...)
+	if err != nil {
+		return nil, err
+	}
+	clientFiles, err := Compile(clientPolicies...)
+	if err != nil {
+		return nil, err
+	}
+
+	nsServer := registryauthorize.NewNetworkServiceRegistryServer(
+		registryauthorize.WithPolicies(serverPolicies...),
+		registryauthorize.WithResourcePathIDsMap(&r.nsServerPathIDs))
+	nseServer := registryauthorize.NewNetworkServiceEndpointRegistryServer(
+		registryauthorize.WithPolicies(serverPolicies...),
+		registryauthorize.WithResourcePathIDsMap(&r.nseServerPathIDs))
+	nsClient := registryauthorize.NewNetworkServiceRegistryClient(
+		registryauthorize.WithPolicies(clientPolicies...),
+		registryauthorize.WithResourcePathIDsMap(&r.nsClientPathIDs))
+	nseClient := registryauthorize.NewNetworkServiceEndpointRegistryClient(
+		registryauthorize.WithPolicies(clientPolicies...),
+		registryauthorize.WithResourcePathIDsMap(&r.nseClientPathIDs))
+
+	if err := checkUnchanged(serverFiles, serverPolicies...); err != nil {
+		return nil, err
+	}
+	if err := checkUnchanged(clientFiles, clientPolicies...); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.serverFiles, r.clientFiles = serverFiles, clientFiles
+		r.nsServer.store(nsServer)
+		r.nseServer.store(nseServer)
+		r.nsClient.store(nsClient)
+		r.nseClient.store(nseClient)
+	}, nil
+}
+
+// RestoreNSE - restores path IDs of the NSE registered before restart, so its refresh is authorized the same way
+// as before restart
+func (r *Registry) RestoreNSE(name string, pathIDs []string) {
+	r.nseServerPathIDs.Store(name, pathIDs)
+}
+
+// Files - returns the loaded server and client policy files
+func (r *Registry) Files() (serverFiles, clientFiles []File) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.serverFiles, r.clientFiles
+}
+
+// NSServer - returns authorize NetworkServiceRegistryServer
+func (r *Registry) NSServer() registry.NetworkServiceRegistryServer {
+	return &r.nsServer
+}
+
+// NSEServer - returns authorize NetworkServiceEndpointRegistryServer
+func (r *Registry) NSEServer() registry.NetworkServiceEndpointRegistryServer {
+	return &r.nseServer
+}
+
+// NSClient - returns authorize NetworkServiceRegistryClient
+func (r *Registry) NSClient() registry.NetworkServiceRegistryClient {
+	return &r.nsClient
+}
+
+// NSEClient - returns authorize NetworkServiceEndpointRegistryClient
+func (r *Registry) NSEClient() registry.NetworkServiceEndpointRegistryClient {
+	return &r.nseClient
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policies
+
+import (
+	"context"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// holder - keeps the current chain element. Chain elements call next via context,
+// so delegating to the stored element keeps the chain intact.
+type holder[T any] struct {
+	current atomic.Pointer[T]
+}
+
+func (h *holder[T]) load() T {
+	return *h.current.Load()
+}
+
+func (h *holder[T]) store(element T) {
+	h.current.Store(&element)
+}
+
+type networkServiceServer struct {
+	holder[networkservice.NetworkServiceServer]
+}
+
+func (s *networkServiceServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	return s.load().Request(ctx, request)
+}
+
+func (s *networkServiceServer) Close(ctx context.Context, conn *networkservice.Connection) (*emptypb.Empty, error) {
+	return s.load().Close(ctx, conn)
+}
+
+type monitorConnectionServer struct {
+	holder[networkservice.MonitorConnectionServer]
+}
+
+func (s *monitorConnectionServer) MonitorConnections(selector *networkservice.MonitorScopeSelector, server networkservice.MonitorConnection_MonitorConnectionsServer) error {
+	return s.load().MonitorConnections(selector, server)
+}
+
+type nsServer struct {
+	holder[registry.NetworkServiceRegistryServer]
+}
+
+func (s *nsServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	return s.load().Register(ctx, ns)
+}
+
+func (s *nsServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return s.load().Find(query, server)
+}
+
+func (s *nsServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*emptypb.Empty, error) {
+	return s.load().Unregister(ctx, ns)
+}
+
+type nseServer struct {
+	holder[registry.NetworkServiceEndpointRegistryServer]
+}
+
+func (s *nseServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return s.load().Register(ctx, nse)
+}
+
+func (s *nseServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return s.load().Find(query, server)
+}
+
+func (s *nseServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*emptypb.Empty, error) {
+	return s.load().Unregister(ctx, nse)
+}
+
+type nsClient struct {
+	holder[registry.NetworkServiceRegistryClient]
+}
+
+func (c *nsClient) Register(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*registry.NetworkService, error) {
+	return c.load().Register(ctx, ns, opts...)
+}
+
+func (c *nsClient) Find(ctx context.Context, query *registry.NetworkServiceQuery, opts ...grpc.CallOption) (registry.NetworkServiceRegistry_FindClient, error) {
+	return c.load().Find(ctx, query, opts...)
+}
+
+func (c *nsClient) Unregister(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return c.load().Unregister(ctx, ns, opts...)
+}
+
+type nseClient struct {
+	holder[registry.NetworkServiceEndpointRegistryClient]
+}
+
+func (c *nseClient) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	return c.load().Register(ctx, nse, opts...)
+}
+
+func (c *nseClient) Find(ctx context.Context, query *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	return c.load().Find(ctx, query, opts...)
+}
+
+func (c *nseClient) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return c.load().Unregister(ctx, nse, opts...)
+}
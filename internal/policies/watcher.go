@@ -0,0 +1,122 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policies
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// watchDelay - delay to collect all the events of one update, e.g. ConfigMap update replaces several files
+const watchDelay = 200 * time.Millisecond
+
+// Watcher - watches directories of the policy files and calls onChange when something is changed there
+type Watcher struct {
+	watcher  *fsnotify.Watcher
+	onChange func()
+
+	mu   sync.Mutex
+	dirs map[string]struct{}
+}
+
+// NewWatcher - creates Watcher and starts watching until ctx is done
+func NewWatcher(ctx context.Context, onChange func()) (*Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create file watcher")
+	}
+	w := &Watcher{
+		watcher:  watcher,
+		onChange: onChange,
+		dirs:     make(map[string]struct{}),
+	}
+	go w.watch(ctx)
+	return w, nil
+}
+
+// Watch - replaces the watched directories with directories of the policy masks. Masks pointing to the policies
+// embedded into sdk have no directories on disk and are skipped.
+func (w *Watcher) Watch(masks ...string) error {
+	dirs := make(map[string]struct{})
+	for _, mask := range masks {
+		dir := filepath.Dir(mask)
+		if info, err := os.Stat(mask); err == nil && info.IsDir() {
+			dir = mask
+		}
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs[dir] = struct{}{}
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for dir := range w.dirs {
+		if _, ok := dirs[dir]; !ok {
+			_ = w.watcher.Remove(dir)
+		}
+	}
+	for dir := range dirs {
+		if _, ok := w.dirs[dir]; ok {
+			continue
+		}
+		if err := w.watcher.Add(dir); err != nil {
+			return errors.Wrapf(err, "failed to watch %s", dir)
+		}
+	}
+	w.dirs = dirs
+	return nil
+}
+
+func (w *Watcher) watch(ctx context.Context) {
+	logger := log.FromContext(ctx).WithField("policies", "Watcher")
+	defer func() { _ = w.watcher.Close() }()
+
+	timer := time.NewTimer(watchDelay)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Rename|fsnotify.Remove) == 0 {
+				continue
+			}
+			logger.Debugf("policies are changed: %v", event)
+			timer.Reset(watchDelay)
+		case <-timer.C:
+			w.onChange()
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			logger.Errorf("file watcher error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pprof provides pprof server which can be enabled, disabled and moved at runtime
+package pprof
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
+)
+
+// Server - pprof server controlled by PprofEnabled and PprofListenOn configuration fields
+type Server struct {
+	ctx      context.Context
+	mu       sync.Mutex
+	server   *http.Server
+	listenOn string
+}
+
+// NewServer - creates stopped Server, the running server is closed when ctx is done
+func NewServer(ctx context.Context) *Server {
+	s := &Server{ctx: ctx}
+	go func() {
+		<-ctx.Done()
+		s.serve(nil, "")
+	}()
+	return s
+}
+
+// Prepare - binds the address the server should listen on according to cfg and returns the function starting,
+// stopping or moving the server. If ctx is done before apply is called, the bound listener is closed, so a failed
+// reload leaves the running server untouched.
+func (s *Server) Prepare(ctx context.Context, cfg *config.Config) (apply func(), err error) {
+	s.mu.Lock()
+	running, listenOn := s.server != nil, s.listenOn
+	s.mu.Unlock()
+
+	if running && cfg.PprofEnabled && cfg.PprofListenOn == listenOn {
+		return func() {}, nil
+	}
+
+	var listener net.Listener
+	if cfg.PprofEnabled {
+		if listener, err = net.Listen("tcp", cfg.PprofListenOn); err != nil {
+			return nil, errors.Wrapf(err, "failed to start profiler on %s", cfg.PprofListenOn)
+		}
+	}
+
+	// Whichever comes first, apply or ctx done, owns the listener
+	var once sync.Once
+	if listener != nil {
+		go func() {
+			<-ctx.Done()
+			once.Do(func() { _ = listener.Close() })
+		}()
+	}
+	return func() {
+		once.Do(func() { s.serve(listener, cfg.PprofListenOn) })
+	}, nil
+}
+
+// serve - stops the running server and starts serving listener if it is not nil
+func (s *Server) serve(listener net.Listener, listenOn string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.server != nil {
+		log.FromContext(s.ctx).Infof("Profiler is stopped on %s", s.listenOn)
+		_ = s.server.Close()
+		s.server = nil
+	}
+	if listener == nil {
+		return
+	}
+	if s.ctx.Err() != nil {
+		_ = listener.Close()
+		return
+	}
+
+	s.listenOn = listenOn
+	s.server = &http.Server{
+		Handler:      newMux(),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+	log.FromContext(s.ctx).Infof("Profiler is enabled. Listening on %s", listenOn)
+	go func(server *http.Server) {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.FromContext(s.ctx).Errorf("Profiler failed: %s", err.Error())
+		}
+	}(s.server)
+}
+
+func newMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pprof_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/pprof"
+)
+
+func TestServer_Prepare(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = busy.Close() }()
+
+	s := pprof.NewServer(ctx)
+
+	// Bind error fails the prepare step
+	_, err = s.Prepare(ctx, &config.Config{PprofEnabled: true, PprofListenOn: busy.Addr().String()})
+	require.Error(t, err)
+
+	free, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	listenOn := free.Addr().String()
+	require.NoError(t, free.Close())
+
+	// Listener of the not applied change is released when the reload is finished
+	prepareCtx, cancelPrepare := context.WithCancel(ctx)
+	_, err = s.Prepare(prepareCtx, &config.Config{PprofEnabled: true, PprofListenOn: listenOn})
+	require.NoError(t, err)
+	cancelPrepare()
+	require.Eventually(t, func() bool {
+		l, listenErr := net.Listen("tcp", listenOn)
+		if listenErr != nil {
+			return false
+		}
+		_ = l.Close()
+		return true
+	}, time.Second, 10*time.Millisecond)
+
+	apply, err := s.Prepare(ctx, &config.Config{PprofEnabled: true, PprofListenOn: listenOn})
+	require.NoError(t, err)
+	apply()
+
+	resp, err := http.Get("http://" + listenOn + "/debug/pprof/cmdline")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	registrynext "github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/spire"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/logging"
+)
+
+const (
+	// RequestRate - reason of NetworkService Request throttled by the request rate limit
+	RequestRate = "request_rate"
+	// RegisterRate - reason of registry Register throttled by the register rate limit
+	RegisterRate = "register_rate"
+	// MaxConnections - reason of NetworkService Request rejected by the connection quota
+	MaxConnections = "max_connections"
+)
+
+// Server - chain elements limiting the calls of each client SPIFFE ID. Calls without peer SPIFFE ID, e.g. made
+// by nsmgr itself, and calls of the exempt peers are not limited, see WithExempt.
+type Server struct {
+	requests        *Limiter
+	registers       *Limiter
+	maxConnections  int
+	spiffeIDConnMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]
+	logger          log.Logger
+	onThrottle      func(ctx context.Context, spiffeID, reason string)
+	exempt          func(id spiffeid.ID) bool
+}
+
+// Option - Server option
+type Option func(s *Server)
+
+// WithRequestRate - limits NetworkService Request calls, including refreshes, to rate per second with burst
+func WithRequestRate(rate float64, burst int) Option {
+	return func(s *Server) {
+		s.requests = NewLimiter(rate, burst)
+	}
+}
+
+// WithRegisterRate - limits NetworkService and NetworkServiceEndpoint Register calls to rate per second with burst
+func WithRegisterRate(rate float64, burst int) Option {
+	return func(s *Server) {
+		s.registers = NewLimiter(rate, burst)
+	}
+}
+
+// WithMaxConnections - limits the number of connections of a client, 0 means unlimited
+func WithMaxConnections(maxConnections int) Option {
+	return func(s *Server) {
+		s.maxConnections = maxConnections
+	}
+}
+
+// WithOnThrottle - sets callback called for every rejected call
+func WithOnThrottle(onThrottle func(ctx context.Context, spiffeID, reason string)) Option {
+	return func(s *Server) {
+		s.onThrottle = onThrottle
+	}
+}
+
+// WithExempt - calls of the peers for which exempt returns true are not limited. Remote nsmgrs and forwarders pass
+// the calls of many clients with their own SPIFFE IDs, so they should be exempt.
+func WithExempt(exempt func(id spiffeid.ID) bool) Option {
+	return func(s *Server) {
+		s.exempt = exempt
+	}
+}
+
+// NewServer - creates Server. Connections of the clients are taken from spiffeIDConnMap filled by the authorize
+// server, so the NetworkServiceServer should be placed before it.
+func NewServer(logger log.Logger, spiffeIDConnMap *genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]], opts ...Option) *Server {
+	s := &Server{
+		requests:        NewLimiter(0, 0),
+		registers:       NewLimiter(0, 0),
+		spiffeIDConnMap: spiffeIDConnMap,
+		logger:          logger,
+		onThrottle:      func(context.Context, string, string) {},
+		exempt:          func(spiffeid.ID) bool { return false },
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NetworkServiceServer - returns chain element limiting NetworkService Request calls
+func (s *Server) NetworkServiceServer() networkservice.NetworkServiceServer {
+	return &networkServiceServer{server: s}
+}
+
+// NSServer - returns chain element limiting NetworkServiceRegistry Register calls
+func (s *Server) NSServer() registry.NetworkServiceRegistryServer {
+	return &nsServer{server: s}
+}
+
+// NSEServer - returns chain element limiting NetworkServiceEndpointRegistry Register calls
+func (s *Server) NSEServer() registry.NetworkServiceEndpointRegistryServer {
+	return &nseServer{server: s}
+}
+
+func (s *Server) throttle(ctx context.Context, id spiffeid.ID, reason, format string, args ...interface{}) error {
+	s.onThrottle(ctx, id.String(), reason)
+	err := status.Errorf(codes.ResourceExhausted, format, args...)
+	s.logger.WithField(logging.SpiffeIDField, id.String()).Warnf("Throttled %s: %s", id.String(), err.Error())
+	return err
+}
+
+// checkConnections - returns error if the client has maxConnections already and connID is not one of them
+func (s *Server) checkConnections(ctx context.Context, id spiffeid.ID, connID string) error {
+	if s.maxConnections <= 0 {
+		return nil
+	}
+	ids, ok := s.spiffeIDConnMap.Load(id)
+	if !ok {
+		return nil
+	}
+	if _, refresh := ids.Load(connID); refresh {
+		return nil
+	}
+	count := 0
+	ids.Range(func(string, struct{}) bool {
+		count++
+		return true
+	})
+	if count >= s.maxConnections {
+		return s.throttle(ctx, id, MaxConnections, "connection quota exceeded for %s: %d connections of %d allowed", id.String(), count, s.maxConnections)
+	}
+	return nil
+}
+
+// limitedPeer - returns SPIFFE ID of the peer if its calls are limited
+func (s *Server) limitedPeer(ctx context.Context) (spiffeid.ID, bool) {
+	id, err := spire.PeerSpiffeIDFromContext(ctx)
+	if err != nil || s.exempt(id) {
+		return spiffeid.ID{}, false
+	}
+	return id, true
+}
+
+func (s *Server) checkRegister(ctx context.Context) error {
+	id, ok := s.limitedPeer(ctx)
+	if !ok || s.registers.Allow(id.String()) {
+		return nil
+	}
+	return s.throttle(ctx, id, RegisterRate, "register rate limit exceeded for %s", id.String())
+}
+
+type networkServiceServer struct {
+	server *Server
+}
+
+func (s *networkServiceServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	id, ok := s.server.limitedPeer(ctx)
+	if !ok {
+		return next.Server(ctx).Request(ctx, request)
+	}
+	if !s.server.requests.Allow(id.String()) {
+		return nil, s.server.throttle(ctx, id, RequestRate, "request rate limit exceeded for %s", id.String())
+	}
+	// Path index points to nsmgr, connections of the client are keyed by the ID of the previous segment
+	path := request.GetConnection().GetPath()
+	if index := int(path.GetIndex()); index > 0 && index < len(path.GetPathSegments()) {
+		if err := s.server.checkConnections(ctx, id, path.GetPathSegments()[index-1].GetId()); err != nil {
+			return nil, err
+		}
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *networkServiceServer) Close(ctx context.Context, conn *networkservice.Connection) (*emptypb.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+type nsServer struct {
+	server *Server
+}
+
+func (s *nsServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	if err := s.server.checkRegister(ctx); err != nil {
+		return nil, err
+	}
+	return registrynext.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *nsServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return registrynext.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *nsServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*emptypb.Empty, error) {
+	return registrynext.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+type nseServer struct {
+	server *Server
+}
+
+func (s *nseServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if err := s.server.checkRegister(ctx); err != nil {
+		return nil, err
+	}
+	return registrynext.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *nseServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return registrynext.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *nseServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*emptypb.Empty, error) {
+	return registrynext.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
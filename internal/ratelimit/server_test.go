@@ -0,0 +1,154 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"testing"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	registrychain "github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/ratelimit"
+)
+
+const clientID = "spiffe://example.org/client"
+
+func withPeerSpiffeID(ctx context.Context, spiffeID string) context.Context {
+	u, _ := url.Parse(spiffeID)
+	return peer.NewContext(ctx, &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{u}}}}},
+	})
+}
+
+func request(clientConnID string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Path: &networkservice.Path{
+				Index: 1,
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "client", Id: clientConnID},
+					{Name: "nsmgr", Id: "nsmgr-" + clientConnID},
+				},
+			},
+		},
+	}
+}
+
+type throttled map[string]int
+
+func (t throttled) onThrottle(_ context.Context, spiffeID, reason string) {
+	t[spiffeID+" "+reason]++
+}
+
+func TestServer_RequestRate(t *testing.T) {
+	counts := throttled{}
+	s := ratelimit.NewServer(log.L(), new(genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]),
+		ratelimit.WithRequestRate(0.001, 2), ratelimit.WithOnThrottle(counts.onThrottle))
+	server := chain.NewNetworkServiceServer(s.NetworkServiceServer())
+
+	ctx := withPeerSpiffeID(context.Background(), clientID)
+	for i := 0; i < 2; i++ {
+		_, err := server.Request(ctx, request("c1"))
+		require.NoError(t, err)
+	}
+	_, err := server.Request(ctx, request("c1"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, 1, counts[clientID+" "+ratelimit.RequestRate])
+
+	// Other clients and calls without peer are not affected
+	_, err = server.Request(withPeerSpiffeID(context.Background(), "spiffe://example.org/other"), request("c2"))
+	require.NoError(t, err)
+	_, err = server.Request(context.Background(), request("c3"))
+	require.NoError(t, err)
+}
+
+func TestServer_MaxConnections(t *testing.T) {
+	counts := throttled{}
+	spiffeIDConnMap := new(genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]])
+	s := ratelimit.NewServer(log.L(), spiffeIDConnMap, ratelimit.WithMaxConnections(2), ratelimit.WithOnThrottle(counts.onThrottle))
+	server := chain.NewNetworkServiceServer(s.NetworkServiceServer())
+
+	ids, _ := spiffeIDConnMap.LoadOrStore(spiffeid.RequireFromString(clientID), new(genericsync.Map[string, struct{}]))
+	ids.Store("c1", struct{}{})
+	ids.Store("c2", struct{}{})
+
+	ctx := withPeerSpiffeID(context.Background(), clientID)
+	_, err := server.Request(ctx, request("c1"))
+	require.NoError(t, err, "refresh of the existing connection is allowed")
+	_, err = server.Request(ctx, request("c3"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Contains(t, err.Error(), "connection quota exceeded")
+	require.Equal(t, 1, counts[clientID+" "+ratelimit.MaxConnections])
+
+	ids.Delete("c2")
+	_, err = server.Request(ctx, request("c3"))
+	require.NoError(t, err)
+}
+
+func TestServer_RegisterRate(t *testing.T) {
+	counts := throttled{}
+	s := ratelimit.NewServer(log.L(), new(genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]),
+		ratelimit.WithRegisterRate(0.001, 1), ratelimit.WithOnThrottle(counts.onThrottle))
+	nseServer := registrychain.NewNetworkServiceEndpointRegistryServer(s.NSEServer())
+	nsServer := registrychain.NewNetworkServiceRegistryServer(s.NSServer())
+
+	ctx := withPeerSpiffeID(context.Background(), clientID)
+	_, err := nseServer.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse"})
+	require.NoError(t, err)
+	_, err = nsServer.Register(ctx, &registry.NetworkService{Name: "ns"})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, 1, counts[clientID+" "+ratelimit.RegisterRate])
+}
+
+func TestServer_Exempt(t *testing.T) {
+	const forwarderID = "spiffe://example.org/ns/nsm-system/sa/forwarder"
+	s := ratelimit.NewServer(log.L(), new(genericsync.Map[spiffeid.ID, *genericsync.Map[string, struct{}]]),
+		ratelimit.WithRequestRate(0.001, 1), ratelimit.WithRegisterRate(0.001, 1),
+		ratelimit.WithExempt(func(id spiffeid.ID) bool { return id.String() == forwarderID }))
+	server := chain.NewNetworkServiceServer(s.NetworkServiceServer())
+	nseServer := registrychain.NewNetworkServiceEndpointRegistryServer(s.NSEServer())
+
+	// Forwarder passes the requests of many clients
+	ctx := withPeerSpiffeID(context.Background(), forwarderID)
+	for i := 0; i < 3; i++ {
+		_, err := server.Request(ctx, request("c1"))
+		require.NoError(t, err)
+		_, err = nseServer.Register(ctx, &registry.NetworkServiceEndpoint{Name: "forwarder"})
+		require.NoError(t, err)
+	}
+
+	ctx = withPeerSpiffeID(context.Background(), clientID)
+	_, err := server.Request(ctx, request("c2"))
+	require.NoError(t, err)
+	_, err = server.Request(ctx, request("c2"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registryfailover
+
+import (
+	"context"
+	"io"
+
+	"github.com/edwarnicke/genericsync"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/clientconn"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+)
+
+type nsClient struct {
+	selector *Selector
+	// registries - registry URL of the last Register by the network service name
+	registries genericsync.Map[string, string]
+}
+
+// NSClient - returns NS registry client element which sends requests to the active registry.
+// It should be placed after the clientconn element and before the dial element.
+func (s *Selector) NSClient() registry.NetworkServiceRegistryClient {
+	return &nsClient{selector: s}
+}
+
+func (c *nsClient) Register(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*registry.NetworkService, error) {
+	ctx = c.selector.withActive(ctx)
+	c.selector.dropStaleConn(ctx, &c.registries, ns.GetName())
+	return next.NetworkServiceRegistryClient(ctx).Register(ctx, ns, opts...)
+}
+
+func (c *nsClient) Find(ctx context.Context, query *registry.NetworkServiceQuery, opts ...grpc.CallOption) (registry.NetworkServiceRegistry_FindClient, error) {
+	return next.NetworkServiceRegistryClient(ctx).Find(c.selector.withActive(ctx), query, opts...)
+}
+
+func (c *nsClient) Unregister(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	c.registries.Delete(ns.GetName())
+	return next.NetworkServiceRegistryClient(ctx).Unregister(c.selector.withActive(ctx), ns, opts...)
+}
+
+type nseClient struct {
+	selector *Selector
+	// registries - registry URL of the last Register by the endpoint name
+	registries genericsync.Map[string, string]
+}
+
+// NSEClient - returns NSE registry client element which sends requests to the active registry.
+// It should be placed after the clientconn element and before the dial element.
+func (s *Selector) NSEClient() registry.NetworkServiceEndpointRegistryClient {
+	return &nseClient{selector: s}
+}
+
+func (c *nseClient) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	ctx = c.selector.withActive(ctx)
+	c.selector.dropStaleConn(ctx, &c.registries, nse.GetName())
+	return next.NetworkServiceEndpointRegistryClient(ctx).Register(ctx, nse, opts...)
+}
+
+func (c *nseClient) Find(ctx context.Context, query *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	return next.NetworkServiceEndpointRegistryClient(ctx).Find(c.selector.withActive(ctx), query, opts...)
+}
+
+func (c *nseClient) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	c.registries.Delete(nse.GetName())
+	return next.NetworkServiceEndpointRegistryClient(ctx).Unregister(c.selector.withActive(ctx), nse, opts...)
+}
+
+// withActive - replaces the registry URL with the active one. URLs not managed by Selector, for example the URLs
+// of the interdomain registries, are not changed.
+func (s *Selector) withActive(ctx context.Context) context.Context {
+	u := clienturlctx.ClientURL(ctx)
+	if u == nil {
+		return ctx
+	}
+	if s.manages(u.String()) {
+		return clienturlctx.WithClientURL(ctx, s.Active())
+	}
+	return ctx
+}
+
+func (s *Selector) manages(u string) bool {
+	for _, managed := range s.urls {
+		if managed.String() == u {
+			return true
+		}
+	}
+	return false
+}
+
+// dropStaleConn - closes and deletes the clientconn of the registration if it was registered on another managed
+// registry. Otherwise the sdk dial element would redial the previous registry to unregister from it and fail the
+// Register if the previous registry is down. The registration on the previous registry expires there.
+func (s *Selector) dropStaleConn(ctx context.Context, registries *genericsync.Map[string, string], name string) {
+	u := clienturlctx.ClientURL(ctx)
+	if u == nil {
+		return
+	}
+	previous, loaded := registries.Load(name)
+	registries.Store(name, u.String())
+	if !loaded || previous == u.String() || !s.manages(previous) {
+		return
+	}
+	if cc, ok := clientconn.LoadAndDelete(ctx); ok {
+		if closer, ok := cc.(io.Closer); ok {
+			_ = closer.Close()
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registryfailover_test
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/clientconn"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/clienturl"
+	registryconnect "github.com/networkservicemesh/sdk/pkg/registry/common/connect"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/dial"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/registryfailover"
+)
+
+func startRegistry(t *testing.T) (*url.URL, registry.NetworkServiceEndpointRegistryServer, func()) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	nseServer := memory.NewNetworkServiceEndpointRegistryServer()
+	server := grpc.NewServer()
+	registry.RegisterNetworkServiceEndpointRegistryServer(server, nseServer)
+	go func() { _ = server.Serve(ln) }()
+	t.Cleanup(server.Stop)
+
+	return &url.URL{Scheme: "tcp", Host: ln.Addr().String()}, nseServer, server.Stop
+}
+
+func findNSE(ctx context.Context, t *testing.T, server registry.NetworkServiceEndpointRegistryServer, name string) []*registry.NetworkServiceEndpoint {
+	stream, err := adapters.NetworkServiceEndpointServerToClient(server).Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: name},
+	})
+	require.NoError(t, err)
+	return registry.ReadNetworkServiceEndpointList(stream)
+}
+
+func TestSelector_RefreshAcrossFailover(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	url1, registry1, stopRegistry1 := startRegistry(t)
+	url2, registry2, _ := startRegistry(t)
+	urls := []*url.URL{url1, url2}
+	h := &healthMap{healthy: map[string]bool{url1.String(): true, url2.String(): true}}
+
+	s := registryfailover.NewSelector(log.L(), urls, h.check)
+	s.CheckNow(ctx)
+	require.Equal(t, url1, s.Active())
+
+	// The same order of the elements as in the sdk nsmgr registry client
+	client := chain.NewNetworkServiceEndpointRegistryClient(
+		begin.NewNetworkServiceEndpointRegistryClient(),
+		clienturl.NewNetworkServiceEndpointRegistryClient(url1),
+		clientconn.NewNetworkServiceEndpointRegistryClient(),
+		s.NSEClient(),
+		dial.NewNetworkServiceEndpointRegistryClient(ctx,
+			dial.WithDialTimeout(time.Second),
+			dial.WithDialOptions(grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock()),
+		),
+		registryconnect.NewNetworkServiceEndpointRegistryClient(),
+	)
+
+	nse := &registry.NetworkServiceEndpoint{Name: "nse", NetworkServiceNames: []string{"ns"}, Url: "tcp://nse:5001"}
+	_, err := client.Register(ctx, nse.Clone())
+	require.NoError(t, err)
+	require.Len(t, findNSE(ctx, t, registry1, "nse"), 1)
+
+	// Refresh after failover goes to the new registry and doesn't try to unregister from the failed one
+	stopRegistry1()
+	h.set(url1, false)
+	s.CheckNow(ctx)
+	require.Equal(t, url2, s.Active())
+
+	_, err = client.Register(ctx, nse.Clone())
+	require.NoError(t, err)
+	require.Len(t, findNSE(ctx, t, registry2, "nse"), 1)
+
+	_, err = client.Unregister(ctx, nse.Clone())
+	require.NoError(t, err)
+	require.Empty(t, findNSE(ctx, t, registry2, "nse"))
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registryfailover provides selection of a healthy registry from a prioritized list of registry URLs
+package registryfailover
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	registryServiceName = "registry.NetworkServiceEndpointRegistry"
+
+	defaultCheckInterval = 5 * time.Second
+)
+
+// CheckFunc - checks health of the registry available by URL
+type CheckFunc func(ctx context.Context, u *url.URL) error
+
+// Selector - periodically checks health of the registries and selects the registry to use.
+// URLs are in priority order. If the active registry becomes unhealthy, Selector fails over to the first healthy
+// registry. If a registry with a higher priority than the active one stays healthy for the fail-back delay,
+// Selector fails back to it.
+type Selector struct {
+	urls          []*url.URL
+	check         CheckFunc
+	checkInterval time.Duration
+	failbackDelay time.Duration
+	logger        log.Logger
+
+	mu           sync.RWMutex
+	active       int
+	healthySince []time.Time
+}
+
+// Option - Selector option
+type Option func(s *Selector)
+
+// WithCheckInterval - sets the interval between registry health checks
+func WithCheckInterval(interval time.Duration) Option {
+	return func(s *Selector) {
+		if interval > 0 {
+			s.checkInterval = interval
+		}
+	}
+}
+
+// WithFailbackDelay - sets the time a registry with higher priority has to stay healthy before Selector fails back
+// to it. 0 disables fail-back.
+func WithFailbackDelay(delay time.Duration) Option {
+	return func(s *Selector) {
+		s.failbackDelay = delay
+	}
+}
+
+// NewSelector - creates Selector for the registry URLs in priority order checked by check. The first URL is active
+// until the first health check.
+func NewSelector(logger log.Logger, urls []*url.URL, check CheckFunc, opts ...Option) *Selector {
+	s := &Selector{
+		urls:          urls,
+		check:         check,
+		checkInterval: defaultCheckInterval,
+		logger:        logger,
+		healthySince:  make([]time.Time, len(urls)),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Active - returns URL of the registry currently in use
+func (s *Selector) Active() *url.URL {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.urls[s.active]
+}
+
+// Run - checks the registries every check interval until ctx is done
+func (s *Selector) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+	for {
+		s.CheckNow(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckNow - checks all the registries and updates the active one
+func (s *Selector) CheckNow(ctx context.Context) {
+	healthy := make([]bool, len(s.urls))
+	var wg sync.WaitGroup
+	for i, u := range s.urls {
+		wg.Add(1)
+		go func(i int, u *url.URL) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, s.checkInterval)
+			defer cancel()
+			if err := s.check(checkCtx, u); err != nil {
+				s.logger.Debugf("Registry %s is not healthy: %v", u.String(), err)
+				return
+			}
+			healthy[i] = true
+		}(i, u)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+	s.update(healthy, time.Now())
+}
+
+func (s *Selector) update(healthy []bool, now time.Time) {
+	s.mu.Lock()
+	for i := range healthy {
+		switch {
+		case !healthy[i]:
+			s.healthySince[i] = time.Time{}
+		case s.healthySince[i].IsZero():
+			s.healthySince[i] = now
+		}
+	}
+	from := s.active
+	s.active = s.selectActive(healthy, now)
+	s.mu.Unlock()
+
+	if from == s.active {
+		return
+	}
+	reason := "fail-back"
+	if !healthy[from] {
+		reason = "failover"
+	}
+	s.logger.Warnf("Registry %s: switching from %s to %s", reason, s.urls[from].String(), s.urls[s.active].String())
+}
+
+func (s *Selector) selectActive(healthy []bool, now time.Time) int {
+	if !healthy[s.active] {
+		for i := range healthy {
+			if healthy[i] {
+				return i
+			}
+		}
+		// Nothing is healthy, keep the active registry
+		return s.active
+	}
+	if s.failbackDelay <= 0 {
+		return s.active
+	}
+	for i := 0; i < s.active; i++ {
+		if healthy[i] && now.Sub(s.healthySince[i]) >= s.failbackDelay {
+			return i
+		}
+	}
+	return s.active
+}
+
+// GRPCCheck - returns CheckFunc which dials the registry with dialOptions and calls grpc health Check for the
+// registry service. Registries not serving grpc health are considered healthy if they are reachable.
+func GRPCCheck(dialOptions ...grpc.DialOption) CheckFunc {
+	return func(ctx context.Context, u *url.URL) error {
+		// nolint:staticcheck
+		cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(u), dialOptions...)
+		if err != nil {
+			return errors.Wrapf(err, "failed to dial %s", u.String())
+		}
+		defer func() { _ = cc.Close() }()
+
+		resp, err := grpc_health_v1.NewHealthClient(cc).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: registryServiceName})
+		switch status.Code(err) {
+		case codes.OK:
+		case codes.Unimplemented, codes.NotFound:
+			return nil
+		default:
+			return errors.Wrapf(err, "failed to check health of %s", u.String())
+		}
+		if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+			return errors.Errorf("registry %s is %s", u.String(), resp.GetStatus().String())
+		}
+		return nil
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registryfailover_test
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/utils/checks/checkcontext"
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/registryfailover"
+)
+
+type healthMap struct {
+	mu      sync.Mutex
+	healthy map[string]bool
+}
+
+func (h *healthMap) set(u *url.URL, healthy bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.healthy[u.String()] = healthy
+}
+
+func (h *healthMap) check(_ context.Context, u *url.URL) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.healthy[u.String()] {
+		return errors.New("unhealthy")
+	}
+	return nil
+}
+
+func newURLs(t *testing.T, rawURLs ...string) []*url.URL {
+	var urls []*url.URL
+	for _, raw := range rawURLs {
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+		urls = append(urls, u)
+	}
+	return urls
+}
+
+func TestSelector_FailoverAndFailback(t *testing.T) {
+	ctx := context.Background()
+	urls := newURLs(t, "tcp://registry-1:5002", "tcp://registry-2:5002", "tcp://registry-3:5002")
+	h := &healthMap{healthy: map[string]bool{urls[1].String(): true, urls[2].String(): true}}
+
+	s := registryfailover.NewSelector(log.L(), urls, h.check, registryfailover.WithFailbackDelay(100*time.Millisecond))
+	require.Equal(t, urls[0], s.Active())
+
+	// Failover to the first healthy registry
+	s.CheckNow(ctx)
+	require.Equal(t, urls[1], s.Active())
+
+	h.set(urls[1], false)
+	s.CheckNow(ctx)
+	require.Equal(t, urls[2], s.Active())
+
+	// Nothing is healthy, the active registry is kept
+	h.set(urls[2], false)
+	s.CheckNow(ctx)
+	require.Equal(t, urls[2], s.Active())
+
+	// Fail-back happens only after the registry has been healthy for the fail-back delay
+	h.set(urls[0], true)
+	h.set(urls[2], true)
+	s.CheckNow(ctx)
+	require.Equal(t, urls[2], s.Active())
+	require.Eventually(t, func() bool {
+		s.CheckNow(ctx)
+		return s.Active() == urls[0]
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSelector_FailbackDisabled(t *testing.T) {
+	ctx := context.Background()
+	urls := newURLs(t, "tcp://registry-1:5002", "tcp://registry-2:5002")
+	h := &healthMap{healthy: map[string]bool{urls[1].String(): true}}
+
+	s := registryfailover.NewSelector(log.L(), urls, h.check, registryfailover.WithFailbackDelay(0))
+	s.CheckNow(ctx)
+	require.Equal(t, urls[1], s.Active())
+
+	h.set(urls[0], true)
+	s.CheckNow(ctx)
+	require.Equal(t, urls[1], s.Active())
+}
+
+func TestSelector_Client(t *testing.T) {
+	ctx := context.Background()
+	urls := newURLs(t, "tcp://registry-1:5002", "tcp://registry-2:5002")
+	interdomainURL := newURLs(t, "tcp://registry.other.org:5002")[0]
+	h := &healthMap{healthy: map[string]bool{urls[1].String(): true}}
+
+	s := registryfailover.NewSelector(log.L(), urls, h.check)
+	s.CheckNow(ctx)
+
+	var expected *url.URL
+	client := chain.NewNetworkServiceEndpointRegistryClient(
+		s.NSEClient(),
+		checkcontext.NewNSEClient(t, func(t *testing.T, ctx context.Context) {
+			require.Equal(t, expected, clienturlctx.ClientURL(ctx))
+		}),
+	)
+
+	expected = urls[1]
+	_, err := client.Register(clienturlctx.WithClientURL(ctx, urls[0]), &registry.NetworkServiceEndpoint{Name: "nse"})
+	require.NoError(t, err)
+
+	expected = interdomainURL
+	_, err = client.Register(clienturlctx.WithClientURL(ctx, interdomainURL), &registry.NetworkServiceEndpoint{Name: "nse"})
+	require.NoError(t, err)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reload provides runtime reload of nsmgr configuration
+package reload
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
+)
+
+// Handler - validates reloadable part of the configuration and returns the function applying it.
+// Apply functions are called only if all the handlers succeeded, so a failed reload leaves nothing applied.
+// ctx is done when the reload is finished, so handlers release resources prepared for apply functions that were not called.
+type Handler func(ctx context.Context, cfg *config.Config) (apply func(), err error)
+
+// Reloader - re-reads configuration and passes reloadable changes to the subscribed handlers.
+// Changes of fields that require restart are logged and ignored.
+type Reloader struct {
+	prefix   string
+	mu       sync.Mutex
+	current  *config.Config
+	handlers []Handler
+}
+
+// NewReloader - creates a Reloader for the configuration loaded with config.Load(prefix)
+func NewReloader(prefix string, current *config.Config) *Reloader {
+	return &Reloader{
+		prefix:  prefix,
+		current: current,
+	}
+}
+
+// Subscribe - adds handler called on every reload
+func (r *Reloader) Subscribe(handler Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers = append(r.handlers, handler)
+}
+
+// Current - returns currently applied configuration
+func (r *Reloader) Current() *config.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.current
+}
+
+// Reload - loads configuration and applies reloadable changes.
+// Handlers are called even if there are no changes, so they can re-read external resources like policy files.
+// If any of the handlers fails, none of the changes is applied and the current configuration is kept,
+// so the next reload retries all the changes.
+func (r *Reloader) Reload(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	logger := log.FromContext(ctx).WithField("reload", "Reload")
+
+	cfg, err := config.Load(r.prefix)
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	for _, change := range config.Diff(r.current, cfg) {
+		if !change.Reloadable {
+			logger.Warnf("change requires restart, ignoring: %s", change)
+			continue
+		}
+		logger.Infof("applying change: %s", change)
+	}
+
+	prepareCtx, cancelPrepare := context.WithCancel(ctx)
+	defer cancelPrepare()
+
+	next := r.current.WithReloadable(cfg)
+	var applies []func()
+	var errs []error
+	for _, handler := range r.handlers {
+		apply, err := handler(prepareCtx, next)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		applies = append(applies, apply)
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("failed to apply configuration: %v", errs)
+	}
+	for _, apply := range applies {
+		apply()
+	}
+	r.current = next
+	return nil
+}
+
+// ReloadOnSignal - starts reloading configuration on every received signal until ctx is done
+func (r *Reloader) ReloadOnSignal(ctx context.Context, signals ...os.Signal) {
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, signals...)
+
+	go func() {
+		defer signal.Stop(signalCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-signalCh:
+				log.FromContext(ctx).Infof("Received %v, reloading configuration", sig)
+				if err := r.Reload(ctx); err != nil {
+					log.FromContext(ctx).Errorf("configuration reload failed: %+v", err)
+				}
+			}
+		}
+	}()
+}
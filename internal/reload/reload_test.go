@@ -0,0 +1,103 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reload_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/reload"
+)
+
+func TestReloader_Reload(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "nsmgr.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("listenOn: [tcp://:5001]\nmaxTokenLifetime: 1s\n"), 0o600))
+	t.Setenv("NSM_CONFIG_FILE", configFile)
+
+	cfg, err := config.Load("nsm")
+	require.NoError(t, err)
+
+	var applied *config.Config
+	r := reload.NewReloader("nsm", cfg)
+	r.Subscribe(func(_ context.Context, cfg *config.Config) (func(), error) {
+		return func() { applied = cfg }, nil
+	})
+
+	require.NoError(t, os.WriteFile(configFile, []byte("listenOn: [tcp://:5002]\nmaxTokenLifetime: 2s\ndialTimeout: 2s\nlogLevel: DEBUG\n"), 0o600))
+	require.NoError(t, r.Reload(context.Background()))
+
+	require.NotNil(t, applied)
+	require.Equal(t, 2*time.Second, applied.MaxTokenLifetime)
+	require.Equal(t, 2*time.Second, applied.DialTimeout)
+	require.Equal(t, "DEBUG", applied.LogLevel)
+	// ListenOn requires restart
+	require.Equal(t, "tcp://:5001", applied.ListenOn[0].String())
+	require.Equal(t, applied, r.Current())
+}
+
+func TestReloader_HandlerError(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "nsmgr.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("maxTokenLifetime: 1s\n"), 0o600))
+	t.Setenv("NSM_CONFIG_FILE", configFile)
+
+	cfg, err := config.Load("nsm")
+	require.NoError(t, err)
+
+	var applied bool
+	var prepareCtx context.Context
+	r := reload.NewReloader("nsm", cfg)
+	r.Subscribe(func(ctx context.Context, _ *config.Config) (func(), error) {
+		prepareCtx = ctx
+		return func() { applied = true }, nil
+	})
+	r.Subscribe(func(_ context.Context, _ *config.Config) (func(), error) {
+		return nil, errors.New("failed")
+	})
+
+	require.NoError(t, os.WriteFile(configFile, []byte("maxTokenLifetime: 2s\n"), 0o600))
+	require.Error(t, r.Reload(context.Background()))
+	require.Equal(t, time.Second, r.Current().MaxTokenLifetime)
+	// The first handler succeeded, but nothing is applied because of the second one
+	require.False(t, applied)
+	// The handler can release resources prepared for the apply function
+	require.Error(t, prepareCtx.Err())
+}
+
+func TestReloader_InvalidFile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "nsmgr.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("dialTimeout: 1s\n"), 0o600))
+	t.Setenv("NSM_CONFIG_FILE", configFile)
+
+	cfg, err := config.Load("nsm")
+	require.NoError(t, err)
+
+	r := reload.NewReloader("nsm", cfg)
+	r.Subscribe(func(_ context.Context, _ *config.Config) (func(), error) {
+		t.Fatal("handler must not be called")
+		return nil, nil
+	})
+
+	require.NoError(t, os.WriteFile(configFile, []byte("dialTimeout: soon\n"), 0o600))
+	require.Error(t, r.Reload(context.Background()))
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package unixsocket creates unix sockets with configured permissions and ownership and removes stale ones
+package unixsocket
+
+import (
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// dialTimeout - timeout of the check whether someone is listening on the existing socket
+const dialTimeout = time.Second
+
+// Config - permissions and ownership of the unix sockets and of the directories created for them.
+// UID and GID -1 mean the owner and the group are not changed.
+type Config struct {
+	DirMode    os.FileMode
+	SocketMode os.FileMode
+	UID        int
+	GID        int
+}
+
+// ParseConfig - parses octal modes and owner and group given as names or numeric IDs, empty owner and group are
+// not changed
+func ParseConfig(dirMode, socketMode, owner, group string) (*Config, error) {
+	c := &Config{UID: -1, GID: -1}
+	var err error
+	if c.DirMode, err = parseMode(dirMode); err != nil {
+		return nil, err
+	}
+	if c.SocketMode, err = parseMode(socketMode); err != nil {
+		return nil, err
+	}
+	if owner != "" {
+		if c.UID, err = lookupID(owner, func(name string) (string, error) {
+			u, lookupErr := user.Lookup(name)
+			if lookupErr != nil {
+				return "", lookupErr
+			}
+			return u.Uid, nil
+		}); err != nil {
+			return nil, errors.Wrapf(err, "invalid unix socket owner %q", owner)
+		}
+	}
+	if group != "" {
+		if c.GID, err = lookupID(group, func(name string) (string, error) {
+			g, lookupErr := user.LookupGroup(name)
+			if lookupErr != nil {
+				return "", lookupErr
+			}
+			return g.Gid, nil
+		}); err != nil {
+			return nil, errors.Wrapf(err, "invalid unix socket group %q", group)
+		}
+	}
+	return c, nil
+}
+
+// Prepare - creates the missing directories of the socket with DirMode and ownership, and removes the socket left
+// by a previous process if no one is listening on it
+func (c *Config) Prepare(socketPath string) (removed bool, err error) {
+	if err = c.mkdirAll(filepath.Dir(socketPath)); err != nil {
+		return false, err
+	}
+	return removeStale(socketPath)
+}
+
+// Listen - listens on the socket and sets SocketMode and ownership of it. Prepare should be called first, an
+// existing socket is replaced only if no one is listening on it, so a restarted listener doesn't take over the
+// socket of another process.
+func (c *Config) Listen(socketPath string) (net.Listener, error) {
+	if _, err := removeStale(socketPath); err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to listen on %s", socketPath)
+	}
+	if err = c.apply(socketPath, c.SocketMode); err != nil {
+		_ = ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+// mkdirAll - creates dir and its missing parents, existing directories are not changed
+func (c *Config) mkdirAll(dir string) error {
+	info, err := os.Stat(dir)
+	if err == nil {
+		if !info.IsDir() {
+			return errors.Errorf("failed to create directory %s for unix socket: it exists and is not a directory", dir)
+		}
+		return nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrapf(err, "failed to create directory %s for unix socket", dir)
+	}
+	if err = c.mkdirAll(filepath.Dir(dir)); err != nil {
+		return err
+	}
+	if err = os.Mkdir(dir, c.DirMode); err != nil && !errors.Is(err, os.ErrExist) {
+		return errors.Wrapf(err, "failed to create directory %s for unix socket", dir)
+	}
+	return c.apply(dir, c.DirMode)
+}
+
+// apply - sets mode, mkdir and listen are affected by umask, and ownership of the file
+func (c *Config) apply(name string, mode os.FileMode) error {
+	if err := os.Chmod(name, mode); err != nil {
+		return errors.Wrapf(err, "failed to change mode of %s", name)
+	}
+	if c.UID == -1 && c.GID == -1 {
+		return nil
+	}
+	if err := os.Chown(name, c.UID, c.GID); err != nil {
+		return errors.Wrapf(err, "failed to change owner of %s", name)
+	}
+	return nil
+}
+
+// removeStale - removes the socket if connections to it are refused
+func removeStale(socketPath string) (bool, error) {
+	info, err := os.Lstat(socketPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to check socket %s", socketPath)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return false, errors.Errorf("%s exists and is not a socket", socketPath)
+	}
+	conn, err := net.DialTimeout("unix", socketPath, dialTimeout)
+	if err == nil {
+		_ = conn.Close()
+		return false, errors.Errorf("another process is listening on %s", socketPath)
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return false, errors.Wrapf(err, "failed to check whether someone is listening on %s", socketPath)
+	}
+	if err = os.Remove(socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, errors.Wrapf(err, "failed to remove stale socket %s", socketPath)
+	}
+	return true, nil
+}
+
+func parseMode(mode string) (os.FileMode, error) {
+	value, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || value > 0o777 {
+		return 0, errors.Errorf("invalid file mode %q, expected octal permissions like 0660", mode)
+	}
+	return os.FileMode(value), nil
+}
+
+func lookupID(value string, lookup func(name string) (string, error)) (int, error) {
+	if id, err := strconv.Atoi(value); err == nil {
+		return id, nil
+	}
+	id, err := lookup(value)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(id)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unixsocket_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/unixsocket"
+)
+
+func TestConfig_Listen(t *testing.T) {
+	c, err := unixsocket.ParseConfig("0750", "0660", "", "")
+	require.NoError(t, err)
+
+	socketPath := filepath.Join(t.TempDir(), "nsm", "nsm.io.sock")
+	removed, err := c.Prepare(socketPath)
+	require.NoError(t, err)
+	require.False(t, removed)
+
+	info, err := os.Stat(filepath.Dir(socketPath))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o750), info.Mode().Perm())
+
+	ln, err := c.Listen(socketPath)
+	require.NoError(t, err)
+	info, err = os.Stat(socketPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o660), info.Mode().Perm())
+
+	// Socket of a running process is not removed
+	_, err = c.Prepare(socketPath)
+	require.ErrorContains(t, err, "another process is listening")
+	_, err = c.Listen(socketPath)
+	require.ErrorContains(t, err, "another process is listening")
+
+	// Socket left by a crashed process is removed
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, ln.Close())
+	removed, err = c.Prepare(socketPath)
+	require.NoError(t, err)
+	require.True(t, removed)
+	_, err = os.Stat(socketPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// Restarted listener replaces the socket left by the failed one
+	ln, err = c.Listen(socketPath)
+	require.NoError(t, err)
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, ln.Close())
+	ln, err = c.Listen(socketPath)
+	require.NoError(t, err)
+	require.NoError(t, ln.Close())
+}
+
+func TestConfig_Prepare_Errors(t *testing.T) {
+	c, err := unixsocket.ParseConfig("0755", "0777", "", "")
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	_, err = c.Prepare(filepath.Join(file, "nsm.io.sock"))
+	require.ErrorContains(t, err, "failed to create directory")
+
+	_, err = c.Prepare(file)
+	require.ErrorContains(t, err, "is not a socket")
+
+	_, err = unixsocket.ParseConfig("rw", "0777", "", "")
+	require.Error(t, err)
+}
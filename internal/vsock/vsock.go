@@ -0,0 +1,131 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vsock listens on and dials vsock://CID:port urls for the workloads running in VMs
+package vsock
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mdlayher/vsock"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Scheme - scheme of the vsock urls
+	Scheme = "vsock"
+
+	// cidAny - VMADDR_CID_ANY, listens on any CID of the host
+	cidAny = ^uint32(0)
+
+	unixScheme = "unix"
+	tcpNetwork = "tcp"
+)
+
+// ParseURL - returns CID and port of vsock://CID:port url, empty CID means any CID and is allowed only for listening
+func ParseURL(u *url.URL) (cid, port uint32, err error) {
+	if u.Scheme != Scheme {
+		return 0, 0, errors.Errorf("%s is not a vsock url", u.String())
+	}
+	host, portValue, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid vsock url %s, expected vsock://CID:port", u.String())
+	}
+	p, err := strconv.ParseUint(portValue, 10, 32)
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid port %q of vsock url %s", portValue, u.String())
+	}
+	if host == "" {
+		return cidAny, uint32(p), nil
+	}
+	c, err := strconv.ParseUint(host, 10, 32)
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid CID %q of vsock url %s", host, u.String())
+	}
+	return uint32(c), uint32(p), nil
+}
+
+// Listen - listens on vsock url, vsock://:port listens on any CID
+func Listen(u *url.URL) (net.Listener, error) {
+	cid, port, err := ParseURL(u)
+	if err != nil {
+		return nil, err
+	}
+	ln, err := vsock.ListenContextID(cid, port, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to listen on %s", u.String())
+	}
+	return ln, nil
+}
+
+// Dial - connects to vsock url
+func Dial(ctx context.Context, u *url.URL) (net.Conn, error) {
+	cid, port, err := ParseURL(u)
+	if err != nil {
+		return nil, err
+	}
+	if cid == cidAny {
+		return nil, errors.Errorf("vsock url %s to dial has no CID", u.String())
+	}
+
+	type result struct {
+		conn net.Conn
+		err  error
+	}
+	// vsock connect doesn't support context, so it is abandoned on cancel and the connection is closed when ready
+	resultCh := make(chan result, 1)
+	go func() {
+		conn, dialErr := vsock.Dial(cid, port, nil)
+		resultCh <- result{conn: conn, err: dialErr}
+	}()
+	select {
+	case r := <-resultCh:
+		if r.err != nil {
+			return nil, errors.Wrapf(r.err, "failed to dial %s", u.String())
+		}
+		return r.conn, nil
+	case <-ctx.Done():
+		go func() {
+			if r := <-resultCh; r.err == nil {
+				_ = r.conn.Close()
+			}
+		}()
+		return nil, errors.Wrapf(ctx.Err(), "failed to dial %s", u.String())
+	}
+}
+
+// DialContext - grpc context dialer connecting to vsock targets. Unix targets are passed by grpc as
+// unix://path or unix:path, other targets are dialed over tcp.
+func DialContext(ctx context.Context, target string) (net.Conn, error) {
+	var dialer net.Dialer
+	switch {
+	case strings.HasPrefix(target, Scheme+"://"):
+		u, err := url.Parse(target)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid vsock target %s", target)
+		}
+		return Dial(ctx, u)
+	case strings.HasPrefix(target, unixScheme+"://"):
+		return dialer.DialContext(ctx, unixScheme, strings.TrimPrefix(target, unixScheme+"://"))
+	case strings.HasPrefix(target, unixScheme+":"):
+		return dialer.DialContext(ctx, unixScheme, strings.TrimPrefix(target, unixScheme+":"))
+	}
+	return dialer.DialContext(ctx, tcpNetwork, target)
+}
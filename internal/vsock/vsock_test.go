@@ -0,0 +1,96 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vsock_test
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-nsmgr/internal/vsock"
+)
+
+func TestParseURL(t *testing.T) {
+	cid, port, err := vsock.ParseURL(&url.URL{Scheme: "vsock", Host: "3:5001"})
+	require.NoError(t, err)
+	require.Equal(t, uint32(3), cid)
+	require.Equal(t, uint32(5001), port)
+
+	cid, _, err = vsock.ParseURL(&url.URL{Scheme: "vsock", Host: ":5001"})
+	require.NoError(t, err)
+	require.Equal(t, ^uint32(0), cid)
+
+	for _, host := range []string{"3", "host:5001", "3:port", "3:-1"} {
+		_, _, err = vsock.ParseURL(&url.URL{Scheme: "vsock", Host: host})
+		require.Error(t, err, host)
+	}
+	_, _, err = vsock.ParseURL(&url.URL{Scheme: "tcp", Host: "3:5001"})
+	require.Error(t, err)
+}
+
+func TestDialContext_UnixAndTCP(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	socket := filepath.Join(t.TempDir(), "test.sock")
+	unixLn, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer func() { _ = unixLn.Close() }()
+	tcpLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = tcpLn.Close() }()
+
+	for _, target := range []string{"unix://" + socket, "unix:" + socket, tcpLn.Addr().String()} {
+		conn, err := vsock.DialContext(ctx, target)
+		require.NoError(t, err, target)
+		_ = conn.Close()
+	}
+}
+
+// TestVsock - requires vsock loopback (modprobe vsock_loopback), CID 1 is the local CID
+func TestVsock(t *testing.T) {
+	ln, err := vsock.Listen(&url.URL{Scheme: "vsock", Host: ":50051"})
+	if err != nil {
+		t.Skipf("vsock is not available: %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+	go func() {
+		conn, acceptErr := ln.Accept()
+		if acceptErr == nil {
+			_, _ = conn.Write([]byte("ok"))
+			_ = conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := vsock.DialContext(ctx, "vsock://1:50051")
+	if err != nil {
+		t.Skipf("vsock loopback is not available: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	buf := make([]byte, 2)
+	_, err = conn.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "ok", string(buf))
+}
@@ -22,21 +22,28 @@ package main
 
 import (
 	"context"
-	"net"
+	"flag"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 
+	"github.com/networkservicemesh/cmd-nsmgr/internal/admin"
 	"github.com/networkservicemesh/cmd-nsmgr/internal/config"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/logging"
 	"github.com/networkservicemesh/cmd-nsmgr/internal/manager"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/metrics"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/pprof"
+	"github.com/networkservicemesh/cmd-nsmgr/internal/reload"
 	"github.com/networkservicemesh/sdk/pkg/tools/debug"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
 	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
-	"github.com/networkservicemesh/sdk/pkg/tools/pprofutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/prometheus"
 )
 
 func main() {
@@ -45,14 +52,14 @@ func main() {
 		context.Background(),
 		os.Interrupt,
 		// More Linux signals here
-		syscall.SIGHUP,
 		syscall.SIGTERM,
 		syscall.SIGQUIT,
 	)
 	defer cancel()
 
 	// Setup logging
-	ctx = log.WithLog(ctx, logruslogger.New(ctx, map[string]interface{}{"cmd": os.Args[0]}))
+	logger := logruslogger.New(ctx, map[string]interface{}{"cmd": os.Args[0]})
+	ctx = log.WithLog(ctx, logger)
 
 	// ********************************************************************************
 	// Debug self if necessary
@@ -61,54 +68,50 @@ func main() {
 		log.FromContext(ctx).Infof("%s", err)
 	}
 
-	// Get cfg from environment
-	cfg := &config.Config{}
-	if err := envconfig.Usage("nsm", cfg); err != nil {
+	// Config file could be passed with --config, it takes precedence over NSM_CONFIG_FILE
+	// and is kept in the environment so it is re-read on reload
+	configFile := flag.String("config", "", "path to a YAML or JSON config file, overrides NSM_CONFIG_FILE")
+	flag.Parse()
+	if *configFile != "" {
+		if err := os.Setenv("NSM_CONFIG_FILE", *configFile); err != nil {
+			log.FromContext(ctx).Fatal(err)
+		}
+	}
+
+	// Get cfg from environment and config file
+	if err := envconfig.Usage("nsm", &config.Config{}); err != nil {
 		log.FromContext(ctx).Fatal(err)
 	}
-	if err := envconfig.Process("nsm", cfg); err != nil {
-		log.FromContext(ctx).Fatalf("error processing cfg from env: %+v", err)
+	cfg, err := config.Load("nsm")
+	if err != nil {
+		log.FromContext(ctx).Fatalf("error loading cfg: %+v", err)
 	}
-
-	// Normalize ListenOn addresses in config
-	for i := range cfg.ListenOn {
-		u := &cfg.ListenOn[i]
-
-		if u.Scheme != "tcp" {
-			continue
-		}
-
-		host := u.Hostname()
-		port := u.Port()
-		if port == "" {
-			continue
-		}
-
-		// If this is a literal IPv6 address, net.Listen requires brackets
-		if ip := net.ParseIP(host); ip != nil && ip.To4() == nil {
-			u.Host = net.JoinHostPort(host, port)
-		}
+	if err = logging.Setup(logger, cfg.LogFormat); err != nil {
+		log.FromContext(ctx).Fatal(err)
 	}
 
 	log.FromContext(ctx).Infof("Using configuration: %v", cfg)
 
-	level, err := logrus.ParseLevel(cfg.LogLevel)
-	if err != nil {
-		log.FromContext(ctx).Fatalf("invalid log level %s", cfg.LogLevel)
+	logLevel := logging.NewLevel(log.FromContext(ctx))
+	if err = setLogLevel(cfg, logLevel); err != nil {
+		log.FromContext(ctx).Fatal(err)
 	}
-	logrus.SetLevel(level)
 	log.EnableTracing(true)
-	logruslogger.SetupLevelChangeOnSignal(ctx, map[os.Signal]logrus.Level{
-		syscall.SIGUSR1: logrus.TraceLevel,
-		syscall.SIGUSR2: level,
-	})
+	logLevel.ChangeOnSignal(ctx, syscall.SIGUSR1, syscall.SIGUSR2)
 
 	// Configure Open Telemetry
+	var metricExporter sdkmetric.Reader
 	if opentelemetry.IsEnabled() {
 		collectorAddress := cfg.OpenTelemetryEndpoint
 		spanExporter := opentelemetry.InitSpanExporter(ctx, collectorAddress)
-		metricExporter := opentelemetry.InitOPTLMetricExporter(ctx, collectorAddress, cfg.MetricsExportInterval)
-		o := opentelemetry.Init(ctx, spanExporter, metricExporter, cfg.Name)
+		metricExporter = opentelemetry.InitOPTLMetricExporter(ctx, collectorAddress, cfg.MetricsExportInterval)
+		otelMetricExporter := metricExporter
+		if cfg.MetricsListenOn != "" {
+			// OTLP reader is added to the meter provider created for Prometheus
+			otelMetricExporter = nil
+		}
+		o := opentelemetry.Init(ctx, spanExporter, otelMetricExporter, cfg.Name)
+		logging.RegisterSpanProcessor()
 		defer func() {
 			if err = o.Close(); err != nil {
 				log.FromContext(ctx).Error(err.Error())
@@ -116,13 +119,58 @@ func main() {
 		}()
 	}
 
+	// Configure Prometheus metrics
+	if cfg.MetricsListenOn != "" {
+		p, promErr := metrics.InitPrometheus(ctx, cfg.Name, metricExporter)
+		if promErr != nil {
+			log.FromContext(ctx).Fatal(promErr)
+		}
+		defer func() {
+			if err = p.Close(); err != nil {
+				log.FromContext(ctx).Error(err.Error())
+			}
+		}()
+		prometheus.NewServer(cfg.MetricsListenOn).ListenAndServe(ctx, cancel)
+	}
+
 	// Configure pprof
-	if cfg.PprofEnabled {
-		go pprofutils.ListenAndServe(ctx, cfg.PprofListenOn)
+	pprofServer := pprof.NewServer(ctx)
+	startPprof, err := pprofServer.Prepare(ctx, cfg)
+	if err != nil {
+		log.FromContext(ctx).Fatal(err)
+	}
+	startPprof()
+
+	// Reload configuration on SIGHUP
+	reloader := reload.NewReloader("nsm", cfg)
+	reloader.Subscribe(func(_ context.Context, cfg *config.Config) (func(), error) {
+		level, levelErr := logrus.ParseLevel(cfg.LogLevel)
+		if levelErr != nil {
+			return nil, errors.Errorf("invalid log level %s", cfg.LogLevel)
+		}
+		return func() { logLevel.SetConfigured(level) }, nil
+	})
+	reloader.Subscribe(pprofServer.Prepare)
+	reloader.ReloadOnSignal(ctx, syscall.SIGHUP)
+
+	// Configure admin server
+	adminServer := admin.NewServer(admin.WithTokenFile(cfg.AdminTokenFile))
+	adminServer.HandleAuthenticated("/loglevel", logLevel)
+	if cfg.AdminListenOn != "" {
+		adminServer.ListenAndServe(ctx, cfg.AdminListenOn)
 	}
 
-	err = manager.RunNsmgr(ctx, cfg)
+	err = manager.RunNsmgr(ctx, cfg, manager.WithReloader(reloader), manager.WithAdminServer(adminServer))
 	if err != nil {
 		log.FromContext(ctx).Fatalf("error executing rootCmd: %v", err)
 	}
 }
+
+func setLogLevel(cfg *config.Config, logLevel *logging.Level) error {
+	level, err := logrus.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return errors.Errorf("invalid log level %s", cfg.LogLevel)
+	}
+	logLevel.SetConfigured(level)
+	return nil
+}
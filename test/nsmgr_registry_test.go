@@ -52,3 +52,31 @@ func (f *NsmgrTestSuite) TestNSMgrEndpointRegister() {
 	require.NotNil(t, regResponse)
 	require.NotEmpty(t, regResponse.Url)
 }
+
+func (f *NsmgrTestSuite) TestNSMgrStandaloneEndpointFind() {
+	t := f.T()
+	setup := newSetup(t)
+	setup.configuration.Standalone = true
+	setup.Start()
+	defer setup.Stop()
+
+	regClient := setup.NewRegistryClient(setup.ctx)
+
+	_, err := regClient.Register(setup.ctx, &registry.NetworkServiceEndpoint{
+		Name:                "my-nse",
+		NetworkServiceNames: []string{"my-service"},
+		Url:                 (&url.URL{Scheme: "unix", Path: path.Join(setup.baseDir, "endpoint.socket")}).String(),
+	})
+	require.NoError(t, err)
+
+	stream, err := regClient.Find(setup.ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			NetworkServiceNames: []string{"my-service"},
+		},
+	})
+	require.NoError(t, err)
+
+	nses := registry.ReadNetworkServiceEndpointList(stream)
+	require.Len(t, nses, 1)
+	require.Equal(t, "my-nse", nses[0].GetName())
+}
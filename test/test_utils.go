@@ -108,6 +108,13 @@ func (s *testSetup) init() {
 	}
 	logrus.Infof("SVID: %q", s.SVid.ID)
 
+	s.configuration.MaxTokenLifetime = time.Hour
+
+	// Standalone nsmgr doesn't need registry
+	if s.configuration.Standalone {
+		return
+	}
+
 	// Setup registry
 	s.registryServer = mockReg.NewServer(
 		&url.URL{Scheme: "tcp", Host: "127.0.0.1:0"},
@@ -116,7 +123,6 @@ func (s *testSetup) init() {
 	require.Nil(s.t, s.registryServer.Start(grpc.Creds(credentials.NewTLS(tlsconfig.MTLSServerConfig(s.Source, s.Source, tlsconfig.AuthorizeAny())))))
 
 	s.configuration.RegistryURL = *s.registryServer.GetListenEndpointURI()
-	s.configuration.MaxTokenLifetime = time.Hour
 }
 
 func (s *testSetup) Start() {